@@ -0,0 +1,129 @@
+package input
+
+import (
+	"context"
+	"fmt"
+	"github.com/liuuner/go-cli-input/cursor"
+	"time"
+)
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+const spinnerInterval = 80 * time.Millisecond
+
+func attachText(s *TextState, update func(apply func()), submit func()) {
+	s.update = update
+	s.submit = submit
+}
+
+// Result of the async validator for the current text, done is false if it was not checked yet
+func (s *TextState) checkResult() (done bool, err error) {
+	if s.hasChecked && s.checked == s.Resolve() {
+		return true, s.checkedErr
+	}
+	return false, nil
+}
+
+// Run the async validator for the current text after delay, spinning while it runs.
+// Keys are read meanwhile, a change of the text cancels the check through stopCheck.
+// If Enter is pending once the check passes, the input is submitted.
+func (s *TextState) startCheck(delay time.Duration) {
+	value := s.Resolve()
+
+	if s.update == nil {
+		// Nothing could redraw the result later, so it is waited for
+		err := s.validateAsync(context.Background(), value)
+		s.checked, s.checkedErr, s.hasChecked = value, err, true
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancelCheck = cancel
+	update := s.update
+
+	go func() {
+		if delay > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+
+		update(func() {
+			if ctx.Err() == nil {
+				s.checking = true
+			}
+		})
+
+		result := make(chan error, 1)
+		go func() {
+			result <- s.validateAsync(ctx, value)
+		}()
+
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case err := <-result:
+				update(func() {
+					// The text changed while the result was on its way
+					if ctx.Err() != nil {
+						return
+					}
+					s.checking = false
+					s.cancelCheck = nil
+					s.err = err
+					s.checked, s.checkedErr, s.hasChecked = value, err, true
+					if s.submitted && err == nil && s.submit != nil {
+						// Finish the Enter which started the check
+						s.submit()
+					}
+				})
+				return
+			case <-ticker.C:
+				update(func() {
+					s.spinnerFrame++
+				})
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Cancel a running or scheduled check
+func (s *TextState) stopCheck() {
+	if s.cancelCheck != nil {
+		s.cancelCheck()
+		s.cancelCheck = nil
+	}
+	s.checking = false
+}
+
+// Show the spinner behind the text, the cursor stays where it is
+func (s *TextState) renderSpinner() {
+	if !s.checking {
+		return
+	}
+
+	shown := len(s.text)
+	if shown == 0 {
+		shown = len(s.defaultText)
+	}
+	behind := shown - s.position
+
+	cursor.MoveHorizontally(behind)
+	fmt.Print(" " + col.Cyan(string(spinnerFrames[s.spinnerFrame%len(spinnerFrames)])))
+	cursor.MoveHorizontally(-behind - 2)
+}
+
+// WithAsyncValidator checks the text against a slow source, like whether a name is taken, showing a spinner meanwhile.
+// The check runs once the text did not change for debounce, or on Enter if debounce is 0 or it did not run yet.
+// Changing the text cancels the context of a running check. After Enter the text is accepted as soon as its check passes.
+func WithAsyncValidator(validate func(ctx context.Context, value string) error, debounce time.Duration) TextOption {
+	return func(s *Input[TextState]) {
+		s.state.validateAsync = validate
+		s.state.debounce = debounce
+	}
+}
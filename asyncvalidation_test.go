@@ -0,0 +1,237 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type check struct {
+	ctx   context.Context
+	value string
+}
+
+// fakeValidator blocks every check until a result is released or its context is canceled
+type fakeValidator struct {
+	started chan check
+	release chan error
+}
+
+func newFakeValidator() *fakeValidator {
+	return &fakeValidator{started: make(chan check, 10), release: make(chan error)}
+}
+
+func (f *fakeValidator) validate(ctx context.Context, value string) error {
+	f.started <- check{ctx, value}
+	select {
+	case err := <-f.release:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// asyncText drives a TextState like Open does, serializing keys and updates
+type asyncText struct {
+	t         *testing.T
+	mu        sync.Mutex
+	s         TextState
+	submitted int // calls of submit
+}
+
+func newAsyncText(t *testing.T, f *fakeValidator, debounce time.Duration) *asyncText {
+	a := &asyncText{t: t, s: NewText("", WithAsyncValidator(f.validate, debounce)).state}
+	attachText(&a.s, func(apply func()) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		apply()
+	}, func() {
+		a.submitted++
+	})
+	return a
+}
+
+func (a *asyncText) press(key keys.Key) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	stop, err := handleText(&a.s, key)
+	if err != nil {
+		a.t.Fatalf("handleText() error = %v", err)
+	}
+	return stop
+}
+
+func (a *asyncText) waitFor(what string, cond func(s *TextState) bool) {
+	a.t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		a.mu.Lock()
+		ok := cond(&a.s)
+		a.mu.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	a.t.Fatalf("timed out waiting for %s", what)
+}
+
+func nextCheck(t *testing.T, f *fakeValidator) check {
+	t.Helper()
+	select {
+	case c := <-f.started:
+		return c
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a check to start")
+		return check{}
+	}
+}
+
+func canceled(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-time.After(2 * time.Second):
+		return false
+	}
+}
+
+var (
+	enter  = keys.Key{Code: keys.Enter}
+	runeOf = func(r rune) keys.Key { return keys.Key{Code: keys.RuneKey, Runes: []rune{r}} }
+)
+
+func TestAsyncValidationOnEnter(t *testing.T) {
+	tests := []struct {
+		name          string
+		result        error
+		wantSubmitted int
+	}{
+		{name: "passing check submits", result: nil, wantSubmitted: 1},
+		{name: "failing check refuses", result: errors.New("taken"), wantSubmitted: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeValidator()
+			a := newAsyncText(t, f, 0)
+
+			a.press(runeOf('a'))
+			if a.press(enter) {
+				t.Fatal("Enter stopped before the check finished")
+			}
+			if c := nextCheck(t, f); c.value != "a" {
+				t.Errorf("checked %q, want \"a\"", c.value)
+			}
+
+			f.release <- tt.result
+			a.waitFor("the result", func(s *TextState) bool { return s.hasChecked && !s.checking })
+
+			a.mu.Lock()
+			submitted := a.submitted
+			a.mu.Unlock()
+			if submitted != tt.wantSubmitted {
+				t.Errorf("submitted %d times, want %d", submitted, tt.wantSubmitted)
+			}
+			// Enter again uses the result instead of checking again
+			if stop := a.press(enter); stop != (tt.result == nil) {
+				t.Errorf("stop = %v, want %v", stop, tt.result == nil)
+			}
+			if !errors.Is(a.s.err, tt.result) {
+				t.Errorf("err = %v, want %v", a.s.err, tt.result)
+			}
+			select {
+			case c := <-f.started:
+				t.Errorf("checked %q again", c.value)
+			default:
+			}
+		})
+	}
+}
+
+func TestAsyncValidationCanceledByEdit(t *testing.T) {
+	f := newFakeValidator()
+	a := newAsyncText(t, f, time.Millisecond)
+
+	a.press(runeOf('a'))
+	first := nextCheck(t, f)
+	a.press(runeOf('b'))
+
+	if !canceled(first.ctx) {
+		t.Fatal("check of the old text not canceled")
+	}
+	second := nextCheck(t, f)
+	if second.value != "ab" {
+		t.Errorf("checked %q, want \"ab\"", second.value)
+	}
+
+	f.release <- nil
+	a.waitFor("the result", func(s *TextState) bool { return s.hasChecked })
+	if a.s.checked != "ab" {
+		t.Errorf("result recorded for %q, want \"ab\"", a.s.checked)
+	}
+	if !a.press(enter) {
+		t.Error("Enter refused after the check passed")
+	}
+}
+
+func TestAsyncValidationKeysReadWhileChecking(t *testing.T) {
+	f := newFakeValidator()
+	a := newAsyncText(t, f, 0)
+
+	a.press(runeOf('a'))
+	a.press(enter)
+	first := nextCheck(t, f)
+
+	// Returns right away although the check hangs
+	done := make(chan struct{})
+	go func() {
+		a.press(keys.Key{Code: keys.Backspace})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("key blocked by the running check")
+	}
+	if !canceled(first.ctx) {
+		t.Error("check not canceled by the edit")
+	}
+}
+
+func TestAsyncValidationCanceledByClose(t *testing.T) {
+	f := newFakeValidator()
+	a := newAsyncText(t, f, 0)
+
+	a.press(runeOf('a'))
+	a.press(enter)
+	c := nextCheck(t, f)
+
+	a.mu.Lock()
+	closeText(&a.s, errors.New("canceled"))
+	a.mu.Unlock()
+
+	if !canceled(c.ctx) {
+		t.Error("check not canceled when the input closed")
+	}
+}
+
+func TestAsyncValidationCheckWhileTypingDoesNotSubmit(t *testing.T) {
+	f := newFakeValidator()
+	a := newAsyncText(t, f, time.Millisecond)
+
+	a.press(runeOf('a'))
+	nextCheck(t, f)
+	f.release <- nil
+	a.waitFor("the result", func(s *TextState) bool { return s.hasChecked })
+
+	if a.submitted != 0 {
+		t.Errorf("submitted %d times without Enter", a.submitted)
+	}
+	if !a.press(enter) {
+		t.Error("Enter refused after the check passed")
+	}
+}
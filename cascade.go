@@ -0,0 +1,155 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"github.com/liuuner/go-cli-input/cursor"
+	"strings"
+)
+
+type cascadeLevel[T any] struct {
+	items     []T
+	cursorPos int
+}
+
+type CascadeState[T any] struct {
+	levels     []cascadeLevel[T] // the last one is shown
+	GetName    func(T) string
+	GetNext    func(path []T) ([]T, error) // items of the level after path, none if the path is complete
+	cursorRune rune
+	err        error
+	lines      int // number of lines rendered last time
+}
+
+// NewCascade chains Selects whose items depend on the choices before, like country → region → city.
+// Choosing an item calls getNext with the path chosen so far, the prompt completes once it returns no items.
+// Left and Backspace return to the previous level.
+func NewCascade[T any](prompt string, roots []T, getName func(T) string, getNext func(path []T) ([]T, error)) Input[CascadeState[T]] {
+	i := newInput[CascadeState[T]]()
+
+	state := CascadeState[T]{
+		levels:     []cascadeLevel[T]{{items: roots}},
+		GetName:    getName,
+		GetNext:    getNext,
+		cursorRune: '❯',
+	}
+
+	return Input[CascadeState[T]]{
+		render:          renderCascade[T],
+		handleInput:     handleCascade[T],
+		close:           closeCascade[T],
+		userPrompt:      prompt,
+		inputPrompt:     "› - Use arrow-keys. Return to choose. Left to go back.",
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+}
+
+func renderCascade[T any](s *CascadeState[T], rerender bool) {
+	if !rerender {
+		cursor.Hide()
+	}
+
+	level := s.level()
+	lines := make([]string, 0, len(level.items)+2)
+	if len(s.levels) > 1 {
+		// Breadcrumb of the choices made so far
+		lines = append(lines, "  "+col.Cyan(s.pathString(s.path()[:len(s.levels)-1]))+col.Gray(" ›"))
+	}
+
+	for index, item := range level.items {
+		cursorString := "   "
+		menuItemText := s.GetName(item)
+		if index == level.cursorPos {
+			cursorString = col.Cyan(string(s.cursorRune), "  ")
+			menuItemText = col.Underline(menuItemText)
+		}
+		lines = append(lines, cursorString+" "+menuItemText)
+	}
+
+	if s.err != nil {
+		lines = append(lines, "    "+col.Red(s.err.Error()))
+	}
+
+	s.lines = drawLines(lines, s.lines)
+}
+
+func handleCascade[T any](s *CascadeState[T], key keys.Key) (stop bool, err error) {
+	// The error of a failed lookup is shown until the next key, which may go back or retry
+	s.err = nil
+
+	level := s.level()
+	switch key.Code {
+	case keys.Up:
+		if len(level.items) > 0 {
+			level.cursorPos = (level.cursorPos - 1 + len(level.items)) % len(level.items)
+		}
+	case keys.Down:
+		if len(level.items) > 0 {
+			level.cursorPos = (level.cursorPos + 1) % len(level.items)
+		}
+	case keys.Left, keys.Backspace:
+		if len(s.levels) > 1 {
+			s.levels = s.levels[:len(s.levels)-1]
+		}
+	case keys.Enter, keys.Right:
+		if len(level.items) == 0 {
+			return key.Code == keys.Enter, nil
+		}
+		next, err := s.GetNext(s.path())
+		if err != nil {
+			s.err = err
+			break
+		}
+		if len(next) == 0 {
+			return key.Code == keys.Enter, nil
+		}
+		s.levels = append(s.levels, cascadeLevel[T]{items: next})
+	}
+
+	return
+}
+
+func closeCascade[T any](s *CascadeState[T], err error) (summary string) {
+	clearLines(s.lines)
+
+	if err != nil {
+		summary = err.Error()
+	} else {
+		summary = s.pathString(s.Resolve())
+	}
+
+	cursor.Show()
+	return
+}
+
+// Resolve returns the chosen item of every level, starting with the first level
+func (s *CascadeState[T]) Resolve() []T {
+	return s.path()
+}
+
+func (s *CascadeState[T]) level() *cascadeLevel[T] {
+	return &s.levels[len(s.levels)-1]
+}
+
+// Items under the cursor of every level
+func (s *CascadeState[T]) path() []T {
+	path := make([]T, 0, len(s.levels))
+	for _, level := range s.levels {
+		if len(level.items) > 0 {
+			path = append(path, level.items[level.cursorPos])
+		}
+	}
+	return path
+}
+
+func (s *CascadeState[T]) pathString(path []T) string {
+	names := make([]string, len(path))
+	for i, item := range path {
+		names[i] = s.GetName(item)
+	}
+	return strings.Join(names, " › ")
+}
@@ -0,0 +1,61 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCascadeRecoversFromFailedLookup(t *testing.T) {
+	fail := true
+	s := NewCascade("", []string{"Germany", "France"}, func(s string) string { return s },
+		func(path []string) ([]string, error) {
+			switch len(path) {
+			case 1:
+				return []string{"Bavaria", "Saxony"}, nil
+			case 2:
+				if fail {
+					return nil, errors.New("lookup failed")
+				}
+				return []string{"Munich"}, nil
+			}
+			return nil, nil
+		},
+	).state
+
+	press := func(code keys.KeyCode) bool {
+		t.Helper()
+		stop, err := handleCascade(&s, keys.Key{Code: code})
+		if err != nil {
+			t.Fatalf("handleCascade() error = %v", err)
+		}
+		return stop
+	}
+
+	press(keys.Enter)
+	press(keys.Enter)
+	if s.err == nil || len(s.levels) != 2 {
+		t.Fatalf("err = %v at level %d, want the lookup error at level 2", s.err, len(s.levels))
+	}
+
+	// Going back clears the error
+	if press(keys.Left) || s.err != nil || len(s.levels) != 1 {
+		t.Fatalf("err = %v at level %d, want no error at level 1", s.err, len(s.levels))
+	}
+
+	// Retrying succeeds
+	press(keys.Enter)
+	press(keys.Enter)
+	fail = false
+	press(keys.Enter)
+	if s.err != nil || len(s.levels) != 3 {
+		t.Fatalf("err = %v at level %d, want no error at level 3", s.err, len(s.levels))
+	}
+	if !press(keys.Enter) {
+		t.Fatal("Enter on the last level did not complete")
+	}
+	if got := s.Resolve(); !slices.Equal(got, []string{"Germany", "Bavaria", "Munich"}) {
+		t.Errorf("Resolve() = %v", got)
+	}
+}
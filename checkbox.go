@@ -5,39 +5,84 @@ import (
 	"fmt"
 	"github.com/liuuner/go-cli-input/colors"
 	"github.com/liuuner/go-cli-input/cursor"
+	"slices"
+	"strconv"
 	"strings"
 )
 
+// CheckboxItem is a line of a Checkbox, either an item or a group header
 type CheckboxItem[T any] struct {
-	value   T
-	checked bool
+	Choice[T]
+	checked  bool
+	isHeader bool
+	header   string // name of the group
+	group    int
 }
 
+type CheckboxOption[T any] func(*Input[CheckboxState[T]])
+
 type CheckboxState[T any] struct {
-	items     []CheckboxItem[T]
-	GetName   func(T) string
-	GetColor  func(T) colors.Formatter
-	cursorPos int
+	items          []CheckboxItem[T]
+	GetName        func(T) string
+	GetColor       func(T) colors.Formatter
+	GetDescription func(T) string // text shown beneath the highlighted item
+	cursorPos      int
+	disabled       func(T) (bool, string) // reports whether an item can't be checked and why
+	minSelected    int
+	maxSelected    int    // 0 means no limit
+	message        string // shown beneath the list until the next key
+	pane           previewPane[T]
+	lines          int // number of lines rendered last time
+	layout         Layout
+	promptWidth    int   // column the items start at in the horizontal layout
+	ordered        bool  // items resolve in the order they were checked in
+	checkOrder     []int // indices of the checked items in the order they were checked in
 }
 
-func NewCheckbox[T any](prompt string, items []T, getName func(T) string) Input[CheckboxState[T]] {
-	i := newInput[CheckboxState[T]]()
+func NewCheckbox[T any](prompt string, items []T, getName func(T) string, opts ...CheckboxOption[T]) Input[CheckboxState[T]] {
+	s := NewCheckboxChoices(prompt, choicesOf(items, getName), opts...)
+	s.state.GetName = getName
+	return s
+}
+
+// NewCheckboxChoices creates a Checkbox over choices, which carry their own label, hint and disabled state
+func NewCheckboxChoices[T any](prompt string, choices []Choice[T], opts ...CheckboxOption[T]) Input[CheckboxState[T]] {
+	checkboxItems := make([]CheckboxItem[T], len(choices))
+	for i, choice := range choices {
+		checkboxItems[i] = CheckboxItem[T]{Choice: choice, checked: false}
+	}
 
-	checkboxItems := make([]CheckboxItem[T], len(items))
-	for i, item := range items {
-		checkboxItems[i] = CheckboxItem[T]{value: item, checked: false}
+	return newCheckbox(prompt, checkboxItems, nil, opts)
+}
+
+// NewCheckboxGroups creates a Checkbox whose items are listed beneath the name of their group,
+// toggling the header of a group checks or unchecks all of its items
+func NewCheckboxGroups[T any](prompt string, groups []Group[T], getName func(T) string, opts ...CheckboxOption[T]) Input[CheckboxState[T]] {
+	var checkboxItems []CheckboxItem[T]
+	for g, group := range groups {
+		checkboxItems = append(checkboxItems, CheckboxItem[T]{isHeader: true, header: group.Name, group: g})
+		for _, choice := range choicesOf(group.Items, getName) {
+			checkboxItems = append(checkboxItems, CheckboxItem[T]{Choice: choice, group: g})
+		}
 	}
 
+	return newCheckbox(prompt, checkboxItems, getName, opts)
+}
+
+func newCheckbox[T any](prompt string, items []CheckboxItem[T], getName func(T) string, opts []CheckboxOption[T]) Input[CheckboxState[T]] {
+	i := newInput[CheckboxState[T]]()
+
 	state := CheckboxState[T]{
-		items:     checkboxItems,
+		items:     items,
 		cursorPos: 0,
 		GetName:   getName,
 	}
 
-	return Input[CheckboxState[T]]{
+	s := Input[CheckboxState[T]]{
 		render:          renderCheckbox[T],
 		handleInput:     handleCheckbox[T],
 		close:           closeCheckbox[T],
+		attach:          attachCheckbox[T],
 		userPrompt:      prompt,
 		inputPrompt:     "› - Use arrow-keys. Return to submit.",
 		hasPrompt:       i.hasPrompt,
@@ -47,71 +92,209 @@ func NewCheckbox[T any](prompt string, items []T, getName func(T) string) Input[
 		promptString:    i.promptString,
 		state:           state,
 	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	if !s.state.isFocusable(s.state.cursorPos) {
+		s.state.move(1)
+	}
+
+	return s
 }
 
 func renderCheckbox[T any](s *CheckboxState[T], rerender bool) {
-	if rerender {
-		// Move cursor to top
-		cursor.UpN(len(s.items) - 1)
-	} else {
+	if !rerender {
 		cursor.Hide()
 	}
 
+	if s.layout == LayoutHorizontal {
+		renderCheckboxHorizontal(s)
+		return
+	}
+
+	lines := make([]string, 0, len(s.items)+1)
+	for index, item := range s.items {
+		if item.isHeader {
+			if index > 0 {
+				lines = append(lines, separatorLine())
+			}
+			lines = append(lines, s.renderHeader(index))
+			continue
+		}
+
+		lines = append(lines, "  "+s.renderItem(index))
+		if index == s.cursorPos && s.GetDescription != nil {
+			lines = append(lines, descriptionLines(s.GetDescription(item.Value))...)
+		}
+	}
+
+	if s.cursorPos < len(s.items) && !s.items[s.cursorPos].isHeader {
+		lines = s.pane.render(lines, s.cursorPos, s.items[s.cursorPos].Value)
+	}
+
+	if s.message != "" {
+		lines = append(lines, "  "+col.Red(s.message))
+	}
+
+	s.lines = drawLines(lines, s.lines)
+}
+
+// Render the items next to each other behind the prompt, wrapping only when they don't fit
+func renderCheckboxHorizontal[T any](s *CheckboxState[T]) {
+	cells := make([]string, 0, len(s.items)+1)
 	for index, item := range s.items {
-		var newline = "\n"
-		if index == len(s.items)-1 {
-			// Adding a new line on the last option will move the cursor position out of range
-			// For out redrawing
-			newline = ""
+		if !item.isHeader {
+			cells = append(cells, s.renderItem(index))
 		}
+	}
+
+	if s.message != "" {
+		cells = append(cells, col.Red(s.message))
+	}
+
+	width := terminalWidth() - 1
+	s.lines = drawInline(wrapCells(cells, width-s.promptWidth-1, width), s.lines, s.promptWidth+1)
+}
 
-		menuItemText := s.GetName(item.value)
-		if s.GetColor != nil {
-			menuItemText = s.GetColor(item.value)(menuItemText)
+// Render the box and the label of the item at index
+func (s *CheckboxState[T]) renderItem(index int) string {
+	item := s.items[index]
+
+	menuItemText := item.Label
+	disabled, reason := s.disabledAt(index)
+	if disabled {
+		menuItemText = col.Dim(menuItemText)
+		if reason != "" {
+			menuItemText += " " + col.Gray("(", reason, ")")
 		}
-		checkboxString := "[ ]"
-		if index == s.cursorPos { // for color or other effects
-			checkboxString = fmt.Sprintf("[%s]", col.Gray("X"))
-			menuItemText = col.Underline(menuItemText)
+	} else if s.GetColor != nil {
+		menuItemText = s.GetColor(item.Value)(menuItemText)
+	}
+	if item.Hint != "" {
+		menuItemText += " " + col.Gray(item.Hint)
+	}
+	checkboxString := s.box(" ")
+	if index == s.cursorPos { // for color or other effects
+		checkboxString = s.box(col.Gray("X"))
+		menuItemText = col.Underline(menuItemText)
+	}
+	if item.checked && s.ordered {
+		// Show the position in the check order instead of X
+		checkboxString = s.box(strconv.Itoa(slices.Index(s.checkOrder, index) + 1))
+	} else if item.checked {
+		checkboxString = s.box("X")
+	}
+	if disabled {
+		checkboxString = col.Dim(checkboxString)
+	}
+
+	return checkboxString + " " + menuItemText
+}
+
+// Render a group header with a box showing whether none, some or all of its items are checked
+func (s *CheckboxState[T]) renderHeader(index int) string {
+	checked, total := 0, 0
+	for _, item := range s.items {
+		if item.isHeader || item.group != s.items[index].group {
+			continue
 		}
+		total++
 		if item.checked {
-			checkboxString = "[X]"
+			checked++
 		}
+	}
+
+	checkboxString := s.box(" ")
+	if checked > 0 && checked == total {
+		checkboxString = s.box("X")
+	} else if checked > 0 {
+		checkboxString = s.box("-")
+	} else if index == s.cursorPos {
+		checkboxString = s.box(col.Gray("X"))
+	}
 
-		fmt.Printf("\r  %s %s%s", checkboxString, menuItemText, newline)
+	headerText := col.Bold(s.items[index].header)
+	if index == s.cursorPos {
+		headerText = col.Underline(headerText)
 	}
+	return fmt.Sprintf("  %s %s", checkboxString, headerText)
 }
 
 func handleCheckbox[T any](s *CheckboxState[T], key keys.Key) (stop bool, err error) {
+	s.message = ""
+
+	if s.layout == LayoutHorizontal {
+		// Left and Right move the cursor, a checks or unchecks all items
+		switch {
+		case key.Code == keys.Left:
+			key.Code = keys.Up
+		case key.Code == keys.Right:
+			key.Code = keys.Down
+		case key.Code == keys.RuneKey && key.Runes[0] == 'a':
+			key.Code = keys.Right
+			if !s.hasUncheckedItems(-1) {
+				key.Code = keys.Left
+			}
+		}
+	}
+
 	switch key.Code {
 	case keys.Up:
-		s.cursorPos--
-		s.keepPosInBoundaries()
+		s.move(-1)
 	case keys.Down:
-		s.cursorPos++
-		s.keepPosInBoundaries()
+		s.move(1)
 	case keys.Left:
 		//select none
 		s.setAllCheckedState(false)
 	case keys.Right:
 		//select all
 		s.setAllCheckedState(true)
+		if s.hasUncheckedItems(-1) {
+			s.message = fmt.Sprintf("You can select at most %d", s.maxSelected)
+		}
 	case keys.Space:
 		//check/uncheck current
-		s.items[s.cursorPos].checked = !s.items[s.cursorPos].checked
+		if s.isDisabled(s.cursorPos) {
+			break
+		}
+		if s.items[s.cursorPos].isHeader {
+			group := s.items[s.cursorPos].group
+			check := s.hasUncheckedItems(group)
+			s.setGroupCheckedState(group, check)
+			if check && s.hasUncheckedItems(group) {
+				s.message = fmt.Sprintf("You can select at most %d", s.maxSelected)
+			}
+			break
+		}
+		if !s.items[s.cursorPos].checked && s.isAtMax() {
+			s.message = fmt.Sprintf("You can select at most %d", s.maxSelected)
+			break
+		}
+		s.setChecked(s.cursorPos, !s.items[s.cursorPos].checked)
 	case keys.Enter:
+		if len(s.getCheckedItems()) < s.minSelected {
+			s.message = fmt.Sprintf("Select at least %d", s.minSelected)
+			break
+		}
+		// Items checked up front may exceed the maximum
+		if s.maxSelected > 0 && len(s.getCheckedItems()) > s.maxSelected {
+			s.message = fmt.Sprintf("You can select at most %d", s.maxSelected)
+			break
+		}
 		stop, err = true, nil
 	}
 
 	return
 }
 
+func attachCheckbox[T any](s *CheckboxState[T], update func(apply func()), _ func()) {
+	s.pane.attach(update)
+}
+
 func closeCheckbox[T any](s *CheckboxState[T], err error) (summary string) {
-	cursor.ClearLine()
-	for i := 0; i < len(s.items)-1; i++ {
-		cursor.Up()
-		cursor.ClearLine()
-	}
+	clearLines(s.lines)
 
 	checkedItems := s.getCheckedItems()
 
@@ -120,7 +303,7 @@ func closeCheckbox[T any](s *CheckboxState[T], err error) (summary string) {
 	} else {
 		names := make([]string, len(checkedItems))
 		for i, item := range checkedItems {
-			names[i] = s.GetName(item.value)
+			names[i] = item.Label
 		}
 		summary = strings.Join(names, ", ")
 	}
@@ -137,14 +320,71 @@ func (s *CheckboxState[T]) Resolve() []T {
 }
 
 func (s *CheckboxState[T]) setAllCheckedState(checked bool) {
-	for i := range s.items {
-		s.items[i].checked = checked
+	s.setGroupCheckedState(-1, checked)
+}
+
+// Check or uncheck every item of a group, or of all groups if group is -1
+func (s *CheckboxState[T]) setGroupCheckedState(group int, checked bool) {
+	for i, item := range s.items {
+		if item.isHeader || s.isDisabled(i) || (group >= 0 && item.group != group) {
+			continue
+		}
+		if checked && !item.checked && s.isAtMax() {
+			break
+		}
+		s.setChecked(i, checked)
 	}
 }
 
+// Whether an item of a group, or of any group if group is -1, could still be checked
+func (s *CheckboxState[T]) hasUncheckedItems(group int) bool {
+	for i, item := range s.items {
+		if !item.isHeader && !item.checked && !s.isDisabled(i) && (group < 0 || item.group == group) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *CheckboxState[T]) setChecked(index int, checked bool) {
+	if s.items[index].checked == checked {
+		return
+	}
+
+	s.items[index].checked = checked
+	if checked {
+		s.checkOrder = append(s.checkOrder, index)
+	} else {
+		s.checkOrder = slices.DeleteFunc(s.checkOrder, func(i int) bool {
+			return i == index
+		})
+	}
+}
+
+// Render a box around content, wide enough for the positions in the check order if they are shown
+func (s *CheckboxState[T]) box(content string) string {
+	width := 1
+	if s.ordered {
+		width = len(strconv.Itoa(len(s.items)))
+	}
+	return "[" + strings.Repeat(" ", width-visibleLen(content)) + content + "]"
+}
+
+// Whether no further item may be checked
+func (s *CheckboxState[T]) isAtMax() bool {
+	return s.maxSelected > 0 && len(s.getCheckedItems()) >= s.maxSelected
+}
+
 func (s *CheckboxState[T]) getCheckedItems() (checkedItems []CheckboxItem[T]) {
+	if s.ordered {
+		for _, index := range s.checkOrder {
+			checkedItems = append(checkedItems, s.items[index])
+		}
+		return checkedItems
+	}
+
 	for _, item := range s.items {
-		if item.checked {
+		if item.checked && !item.isHeader {
 			checkedItems = append(checkedItems, item)
 		}
 	}
@@ -154,7 +394,7 @@ func (s *CheckboxState[T]) getCheckedItems() (checkedItems []CheckboxItem[T]) {
 func (s *CheckboxState[T]) toItems(items []CheckboxItem[T]) []T {
 	nonCheckboxItems := make([]T, len(items))
 	for i, checkboxItem := range items {
-		nonCheckboxItems[i] = checkboxItem.value
+		nonCheckboxItems[i] = checkboxItem.Value
 	}
 	return nonCheckboxItems
 }
@@ -162,3 +402,159 @@ func (s *CheckboxState[T]) toItems(items []CheckboxItem[T]) []T {
 func (s *CheckboxState[T]) keepPosInBoundaries() {
 	s.cursorPos = (s.cursorPos + len(s.items)) % len(s.items)
 }
+
+// Move the cursor by dir to the next item it can be placed on
+func (s *CheckboxState[T]) move(dir int) {
+	start := s.cursorPos
+	for i := 0; i < len(s.items); i++ {
+		s.cursorPos += dir
+		s.keepPosInBoundaries()
+		if s.isFocusable(s.cursorPos) {
+			return
+		}
+	}
+	s.cursorPos = start
+}
+
+// Whether the cursor can be placed on the item at index
+func (s *CheckboxState[T]) isFocusable(index int) bool {
+	if index >= len(s.items) || s.isDisabled(index) {
+		return false
+	}
+	// Group headers are not shown in the horizontal layout
+	return s.layout != LayoutHorizontal || !s.items[index].isHeader
+}
+
+func (s *CheckboxState[T]) isDisabled(index int) bool {
+	disabled, _ := s.disabledAt(index)
+	return disabled
+}
+
+// Whether the item at index can't be checked and the reason why
+func (s *CheckboxState[T]) disabledAt(index int) (bool, string) {
+	if index >= len(s.items) || s.items[index].isHeader {
+		return false, ""
+	}
+	if item := s.items[index]; item.Disabled {
+		return true, item.DisabledReason
+	}
+	if s.disabled == nil {
+		return false, ""
+	}
+	return s.disabled(s.items[index].Value)
+}
+
+// Index of the n-th item not counting group headers, -1 if there is none
+func (s *CheckboxState[T]) itemIndex(n int) int {
+	for index, item := range s.items {
+		if item.isHeader {
+			continue
+		}
+		if n == 0 {
+			return index
+		}
+		n--
+	}
+	return -1
+}
+
+// WithCheckboxCursor sets the initially highlighted item by its index, group headers are not counted
+func WithCheckboxCursor[T any](index int) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		if pos := s.state.itemIndex(index); pos >= 0 {
+			s.state.cursorPos = pos
+		}
+	}
+}
+
+// WithCheckboxCursorFunc highlights the first item matching initially
+func WithCheckboxCursorFunc[T any](match func(T) bool) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		for index, item := range s.state.items {
+			if !item.isHeader && match(item.Value) {
+				s.state.cursorPos = index
+				return
+			}
+		}
+	}
+}
+
+// WithChecked checks the items at the given indices initially, group headers are not counted
+func WithChecked[T any](indices ...int) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		for _, index := range indices {
+			if pos := s.state.itemIndex(index); pos >= 0 {
+				s.state.setChecked(pos, true)
+			}
+		}
+	}
+}
+
+// WithCheckedFunc checks every item matching initially
+func WithCheckedFunc[T any](match func(T) bool) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		for index, item := range s.state.items {
+			if !item.isHeader && match(item.Value) {
+				s.state.setChecked(index, true)
+			}
+		}
+	}
+}
+
+// WithMinSelected refuses to submit until at least n items are checked
+func WithMinSelected[T any](n int) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		s.state.minSelected = n
+	}
+}
+
+// WithMaxSelected refuses to check more than n items
+func WithMaxSelected[T any](n int) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		s.state.maxSelected = n
+	}
+}
+
+// WithCheckboxDisabled marks items for which disabled returns true as not checkable,
+// the optional reason is shown next to the item
+func WithCheckboxDisabled[T any](disabled func(T) (bool, string)) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		s.state.disabled = disabled
+	}
+}
+
+// WithCheckboxDescription shows the text returned by getDescription dimmed beneath the highlighted item
+func WithCheckboxDescription[T any](getDescription func(T) string) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		s.state.GetDescription = getDescription
+	}
+}
+
+// WithCheckboxPreview shows a panel rendered by preview for the highlighted item below or beside the list.
+// preview is called in the background, so it may take its time.
+func WithCheckboxPreview[T any](preview func(T) string, position PreviewPosition) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		s.state.pane.preview = preview
+		s.state.pane.position = position
+	}
+}
+
+// WithCheckboxLayout arranges the items one per line or next to each other on the prompt line
+func WithCheckboxLayout[T any](layout Layout) CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		s.state.layout = layout
+		s.isLevelWithPrompt = layout == LayoutHorizontal
+		if layout == LayoutHorizontal {
+			s.inputPrompt = "› - a to toggle all"
+			s.state.promptWidth = promptWidth(s)
+		}
+	}
+}
+
+// WithCheckOrder resolves the checked items in the order they were checked in,
+// which is shown in the box of an item instead of X
+func WithCheckOrder[T any]() CheckboxOption[T] {
+	return func(s *Input[CheckboxState[T]]) {
+		s.state.ordered = true
+	}
+}
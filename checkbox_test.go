@@ -0,0 +1,38 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"strconv"
+	"testing"
+)
+
+func TestCheckboxEnterLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []CheckboxOption[int]
+		wantStop bool
+	}{
+		{name: "no limits", wantStop: true},
+		{name: "below min", opts: []CheckboxOption[int]{WithMinSelected[int](2), WithChecked[int](0)}, wantStop: false},
+		{name: "at min", opts: []CheckboxOption[int]{WithMinSelected[int](2), WithChecked[int](0, 1)}, wantStop: true},
+		{name: "at max", opts: []CheckboxOption[int]{WithMaxSelected[int](2), WithChecked[int](0, 1)}, wantStop: true},
+		{name: "checked up front above max", opts: []CheckboxOption[int]{WithMaxSelected[int](2), WithChecked[int](0, 1, 2)}, wantStop: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCheckbox("", numbers(4), strconv.Itoa, tt.opts...).state
+
+			stop, err := handleCheckbox(&s, keys.Key{Code: keys.Enter})
+			if err != nil {
+				t.Fatalf("handleCheckbox() error = %v", err)
+			}
+			if stop != tt.wantStop {
+				t.Errorf("stop = %v, want %v", stop, tt.wantStop)
+			}
+			if !stop && s.message == "" {
+				t.Errorf("refused without a message")
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package input
+
+// Choice is an item of a list together with how it is presented
+type Choice[T any] struct {
+	Value          T
+	Label          string
+	Hint           string // shown dimmed next to the label
+	Key            rune   // shortcut choosing the item, 0 if there is none
+	Disabled       bool
+	DisabledReason string
+}
+
+// Choices labeled by getName
+func choicesOf[T any](items []T, getName func(T) string) []Choice[T] {
+	choices := make([]Choice[T], len(items))
+	for i, item := range items {
+		choices[i] = Choice[T]{Value: item, Label: getName(item)}
+	}
+	return choices
+}
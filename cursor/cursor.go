@@ -36,3 +36,19 @@ func MoveHorizontally(offset int) {
 		fmt.Printf("\033[%dD", -offset) // Move left
 	}
 }
+
+func ClearDown() {
+	fmt.Print("\u001B[J") // Clear from the cursor to the end of the screen
+}
+
+func DownN(n int) {
+	fmt.Printf("\u001B[%dB", n)
+}
+
+func Save() {
+	fmt.Print("\u001B7") // Save the cursor position
+}
+
+func Restore() {
+	fmt.Print("\u001B8") // Return to the saved cursor position
+}
@@ -0,0 +1,270 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"fmt"
+	"github.com/liuuner/go-cli-input/cursor"
+	"strings"
+	"time"
+)
+
+// Layout of the dates which can be typed to jump to them
+const isoDate = "2006-01-02"
+
+type DateState struct {
+	date         time.Time // the highlighted day, at midnight
+	min, max     time.Time // zero if the dates are not bounded
+	weekStart    time.Weekday
+	monthNames   [12]string
+	weekdayNames [7]string // starting with Sunday like time.Weekday
+	typed        []rune    // part of a date typed in ISO format
+	message      string    // shown beneath the calendar until the next key
+	lines        int       // number of lines rendered last time
+}
+
+type DateOption func(*Input[DateState])
+
+// NewDate shows a month calendar to pick a day from, starting at today.
+// The arrow keys move by a day or a week, PgUp and PgDown by a month and typing a date like 2024-12-31 jumps to it.
+func NewDate(prompt string, opts ...DateOption) Input[DateState] {
+	i := newInput[DateState]()
+
+	state := DateState{
+		date:      dateOf(time.Now()),
+		weekStart: time.Monday,
+		monthNames: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		weekdayNames: [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+		typed:        []rune{},
+	}
+
+	s := Input[DateState]{
+		render:          renderDate,
+		handleInput:     handleDate,
+		close:           closeDate,
+		userPrompt:      prompt,
+		inputPrompt:     "› - Use arrow-keys. PgUp/PgDown to change month. Type a date to jump. Return to submit.",
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+	// Bounds are compared by their day, so they are moved to the time zone of the value
+	s.state.min = dayIn(s.state.min, s.state.date.Location())
+	s.state.max = dayIn(s.state.max, s.state.date.Location())
+	s.state.date = s.state.clamp(s.state.date)
+
+	return s
+}
+
+func renderDate(s *DateState, rerender bool) {
+	if !rerender {
+		cursor.Hide()
+	}
+
+	year, month, _ := s.date.Date()
+	first := time.Date(year, month, 1, 0, 0, 0, 0, s.date.Location())
+	days := first.AddDate(0, 1, -1).Day()
+
+	lines := make([]string, 0, 9)
+	lines = append(lines, fmt.Sprintf("  %s %s %s", col.Gray("‹"), col.Bold(fmt.Sprintf("%s %d", s.monthNames[month-1], year)), col.Gray("›")))
+
+	header := make([]string, 7)
+	for i := range header {
+		header[i] = fmt.Sprintf("%2s", s.weekdayNames[(int(s.weekStart)+i)%7])
+	}
+	lines = append(lines, "  "+col.Gray(strings.Join(header, " ")))
+
+	// Blank cells before the first day of the month
+	offset := (int(first.Weekday()) - int(s.weekStart) + 7) % 7
+	cells := make([]string, offset, offset+days)
+	for i := range cells {
+		cells[i] = "  "
+	}
+	for day := 1; day <= days; day++ {
+		date := first.AddDate(0, 0, day-1)
+		cell := fmt.Sprintf("%2d", day)
+		if date.Equal(s.date) {
+			cell = col.Inverse(col.Cyan(cell))
+		} else if !s.inRange(date) {
+			cell = col.Dim(cell)
+		}
+		cells = append(cells, cell)
+	}
+	for week := 0; week < len(cells); week += 7 {
+		lines = append(lines, "  "+strings.Join(cells[week:min(week+7, len(cells))], " "))
+	}
+
+	if s.message != "" {
+		lines = append(lines, "  "+col.Red(s.message))
+	} else if len(s.typed) > 0 {
+		lines = append(lines, "  "+col.Gray("Go to: ")+string(s.typed))
+	}
+
+	s.lines = drawLines(lines, s.lines)
+}
+
+func handleDate(s *DateState, key keys.Key) (stop bool, err error) {
+	s.message = ""
+
+	switch key.Code {
+	case keys.Left:
+		s.moveTo(s.date.AddDate(0, 0, -1))
+	case keys.Right:
+		s.moveTo(s.date.AddDate(0, 0, 1))
+	case keys.Up:
+		s.moveTo(s.date.AddDate(0, 0, -7))
+	case keys.Down:
+		s.moveTo(s.date.AddDate(0, 0, 7))
+	case keys.PgUp:
+		s.moveTo(addMonths(s.date, -1))
+	case keys.PgDown:
+		s.moveTo(addMonths(s.date, 1))
+	case keys.Backspace:
+		if len(s.typed) > 0 {
+			s.typed = s.typed[:len(s.typed)-1]
+		}
+	case keys.RuneKey:
+		s.typeDate(key.Runes)
+	case keys.Enter:
+		stop, err = true, nil
+	}
+
+	return
+}
+
+func closeDate(s *DateState, err error) (summary string) {
+	clearLines(s.lines)
+
+	if err != nil {
+		summary = err.Error()
+	} else {
+		summary = s.date.Format(isoDate)
+	}
+
+	cursor.Show()
+	return
+}
+
+// Resolve returns the chosen day at midnight
+func (s *DateState) Resolve() time.Time {
+	return s.date
+}
+
+// Highlight date, or the nearest day in range
+func (s *DateState) moveTo(date time.Time) {
+	s.typed = s.typed[:0]
+	s.date = s.clamp(date)
+}
+
+// Add typed runes to the date being typed and jump to it once it is complete
+func (s *DateState) typeDate(runes []rune) {
+	if strings.Trim(string(runes), "0123456789-") != "" {
+		// Only dates can be typed
+		return
+	}
+
+	s.typed = append(s.typed, runes...)
+	if len(s.typed) < len(isoDate) {
+		return
+	}
+
+	typed := string(s.typed)
+	s.typed = s.typed[:0]
+	date, err := time.ParseInLocation(isoDate, typed, s.date.Location())
+	if err != nil {
+		s.message = fmt.Sprintf("%q is not a date like YYYY-MM-DD", typed)
+		return
+	}
+	if !s.inRange(date) {
+		s.message = fmt.Sprintf("%s is out of range", typed)
+		return
+	}
+	s.date = date
+}
+
+func (s *DateState) inRange(date time.Time) bool {
+	return (s.min.IsZero() || !date.Before(s.min)) && (s.max.IsZero() || !date.After(s.max))
+}
+
+func (s *DateState) clamp(date time.Time) time.Time {
+	if !s.min.IsZero() && date.Before(s.min) {
+		return s.min
+	}
+	if !s.max.IsZero() && date.After(s.max) {
+		return s.max
+	}
+	return date
+}
+
+// Midnight of the day of t
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// Midnight in loc of the same year, month and day as date, zero if date is zero
+func dayIn(date time.Time, loc *time.Location) time.Time {
+	if date.IsZero() {
+		return date
+	}
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// Add months to date, staying at the last day of a shorter month instead of overflowing into the next one
+func addMonths(date time.Time, months int) time.Time {
+	year, month, day := date.Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+// WithDateValue sets the day highlighted at first, today by default
+func WithDateValue(date time.Time) DateOption {
+	return func(s *Input[DateState]) {
+		s.state.date = dateOf(date)
+	}
+}
+
+// WithDateMin disables the days before date
+func WithDateMin(date time.Time) DateOption {
+	return func(s *Input[DateState]) {
+		s.state.min = dateOf(date)
+	}
+}
+
+// WithDateMax disables the days after date
+func WithDateMax(date time.Time) DateOption {
+	return func(s *Input[DateState]) {
+		s.state.max = dateOf(date)
+	}
+}
+
+// WithDateWeekStart sets the first day of a week in the calendar, Monday by default
+func WithDateWeekStart(day time.Weekday) DateOption {
+	return func(s *Input[DateState]) {
+		s.state.weekStart = day
+	}
+}
+
+// WithDateMonthNames sets the names of the months, starting with January
+func WithDateMonthNames(names [12]string) DateOption {
+	return func(s *Input[DateState]) {
+		s.state.monthNames = names
+	}
+}
+
+// WithDateWeekdayNames sets the names of the days in the header of the calendar, starting with Sunday.
+// They are meant to be two characters long.
+func WithDateWeekdayNames(names [7]string) DateOption {
+	return func(s *Input[DateState]) {
+		s.state.weekdayNames = names
+	}
+}
@@ -0,0 +1,115 @@
+package input
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddMonths(t *testing.T) {
+	tests := []struct {
+		date   time.Time
+		months int
+		want   string
+	}{
+		{date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), months: 1, want: "2024-02-15"},
+		{date: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), months: 1, want: "2024-02-29"},
+		{date: time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), months: 1, want: "2023-02-28"},
+		{date: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), months: -1, want: "2024-02-29"},
+		{date: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), months: 2, want: "2025-02-28"},
+		{date: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), months: -2, want: "2023-11-30"},
+	}
+	for _, tt := range tests {
+		if got := addMonths(tt.date, tt.months).Format(isoDate); got != tt.want {
+			t.Errorf("addMonths(%s, %d) = %s, want %s", tt.date.Format(isoDate), tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestDateTypeDate(t *testing.T) {
+	start := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		typed       string
+		want        string
+		wantMessage bool
+	}{
+		{name: "jumps to a complete date", typed: "2024-01-15", want: "2024-01-15"},
+		{name: "waits for a complete date", typed: "2024-01", want: "2024-01-10"},
+		{name: "ignores letters", typed: "2024-0a1-15", want: "2024-01-15"},
+		{name: "invalid date", typed: "2024-02-30", want: "2024-01-10", wantMessage: true},
+		{name: "before min", typed: "2023-12-31", want: "2024-01-10", wantMessage: true},
+		{name: "after max", typed: "2024-01-21", want: "2024-01-10", wantMessage: true},
+		{name: "at max", typed: "2024-01-20", want: "2024-01-20"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewDate("",
+				WithDateValue(start),
+				WithDateMin(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				WithDateMax(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+			).state
+			for _, r := range tt.typed {
+				s.typeDate([]rune{r})
+			}
+			if got := s.date.Format(isoDate); got != tt.want {
+				t.Errorf("date = %s, want %s", got, tt.want)
+			}
+			if (s.message != "") != tt.wantMessage {
+				t.Errorf("message = %q, want message %v", s.message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDateBoundsInOtherTimeZone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data is not available")
+	}
+
+	s := NewDate("",
+		WithDateValue(time.Date(2024, 1, 10, 12, 0, 0, 0, newYork)),
+		WithDateMin(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		WithDateMax(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	).state
+
+	for _, day := range []int{5, 20} {
+		if date := time.Date(2024, 1, day, 0, 0, 0, 0, newYork); !s.inRange(date) {
+			t.Errorf("inRange(%s) = false, want the bound itself in range", date.Format(isoDate))
+		}
+	}
+	for _, day := range []int{4, 21} {
+		if date := time.Date(2024, 1, day, 0, 0, 0, 0, newYork); s.inRange(date) {
+			t.Errorf("inRange(%s) = true, want false", date.Format(isoDate))
+		}
+	}
+
+	for _, r := range "2024-01-20" {
+		s.typeDate([]rune{r})
+	}
+	if s.message != "" {
+		t.Errorf("message = %q, want the max to be typeable", s.message)
+	}
+	if got := s.date.Format(isoDate); got != "2024-01-20" {
+		t.Errorf("date = %s, want 2024-01-20", got)
+	}
+}
+
+func TestDateClamp(t *testing.T) {
+	s := NewDate("",
+		WithDateValue(time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)),
+		WithDateMax(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	).state
+	if got := s.date.Format(isoDate); got != "2024-01-20" {
+		t.Errorf("date = %s, want the value clamped to 2024-01-20", got)
+	}
+
+	s.moveTo(s.date.AddDate(0, 0, 7))
+	if got := s.date.Format(isoDate); got != "2024-01-20" {
+		t.Errorf("date = %s, want to stay at the max", got)
+	}
+	s.moveTo(addMonths(s.date, -1))
+	if got := s.date.Format(isoDate); got != "2023-12-20" {
+		t.Errorf("date = %s, want 2023-12-20", got)
+	}
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/liuuner/go-cli-input"
+	"github.com/liuuner/go-cli-input/validators"
+	"time"
 )
 
 func main() {
@@ -28,7 +32,10 @@ func main() {
 	selectedOption := state.Resolve()
 
 	// Text input for email
-	s2 := input.NewText("Enter your Email:", input.WithDefaultText("example@mail.com"))
+	s2 := input.NewText("Enter your Email:",
+		input.WithDefaultText("example@mail.com"),
+		input.WithValidator(validators.Email()),
+	)
 
 	state2, err := s2.Open()
 	if err != nil {
@@ -45,6 +52,25 @@ func main() {
 	}
 	password := state3.Resolve()
 
+	// Text input checked against a slow source, faked by waiting
+	s6 := input.NewText("Name your Project:", input.WithAsyncValidator(func(ctx context.Context, name string) error {
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if name == "taken" {
+			return errors.New("this name is taken")
+		}
+		return nil
+	}, 500*time.Millisecond))
+
+	state6, err := s6.Open()
+	if err != nil {
+		return
+	}
+	project := state6.Resolve()
+
 	// Checkbox input
 	s4 := input.NewCheckbox("Select one or more options:", items, func(s string) string {
 		return s
@@ -69,6 +95,7 @@ func main() {
 	fmt.Println("Selected Option:", selectedOption)
 	fmt.Println("Entered Email:", email)
 	fmt.Println("Entered Password:", password)
+	fmt.Println("Project Name:", project)
 	fmt.Println("Selected Checkboxes:", selectedCheckboxes)
 	fmt.Println("Confirmation:", confirmation)
 
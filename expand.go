@@ -0,0 +1,104 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"fmt"
+	"github.com/liuuner/go-cli-input/cursor"
+	"unicode"
+)
+
+// Key which expands the prompt into the full list of choices
+const expandHelpKey = 'h'
+
+type ExpandState[T any] struct {
+	SelectState[T] // list shown once expanded
+	expanded       bool
+	hasDefault     bool // defaultIndex pointed at a choice, so Enter chooses it
+}
+
+// NewExpand creates a compact prompt like "Overwrite file? (yNadh)" choosing a choice by its Key.
+// Enter chooses the default choice and h expands the prompt into a list like NewSelect.
+// Choices without a Key, or with the reserved Key h, are left out of the hint and can be chosen from the list only.
+// If defaultIndex is not the index of a choice, Enter does nothing until the list is expanded.
+func NewExpand[T any](prompt string, choices []Choice[T], defaultIndex int) Input[ExpandState[T]] {
+	i := newInput[ExpandState[T]]()
+
+	list := NewSelectChoices(prompt, choices, WithShortcutsChoose[T](), WithSelectCursor[T](defaultIndex))
+
+	keyString := ""
+	for index, choice := range choices {
+		if choice.Key == 0 || unicode.ToLower(choice.Key) == expandHelpKey {
+			continue
+		}
+		if index == defaultIndex {
+			keyString += string(unicode.ToUpper(choice.Key))
+		} else {
+			keyString += string(unicode.ToLower(choice.Key))
+		}
+	}
+
+	return Input[ExpandState[T]]{
+		render:            renderExpand[T],
+		handleInput:       handleExpand[T],
+		close:             closeExpand[T],
+		userPrompt:        prompt,
+		inputPrompt:       fmt.Sprintf("(%s%c) ", keyString, expandHelpKey),
+		hasPrompt:         i.hasPrompt,
+		hasSummary:        i.hasSummary,
+		failedString:      i.failedString,
+		completedString:   i.completedString,
+		promptString:      i.promptString,
+		isLevelWithPrompt: true,
+		state: ExpandState[T]{
+			SelectState: list.state,
+			hasDefault:  defaultIndex >= 0 && defaultIndex < len(choices),
+		},
+	}
+}
+
+func renderExpand[T any](s *ExpandState[T], _ bool) {
+	if !s.expanded {
+		return
+	}
+
+	if s.lines == 0 {
+		// The list starts beneath the prompt
+		fmt.Print("\n")
+		renderSelect(&s.SelectState, false)
+	} else {
+		renderSelect(&s.SelectState, true)
+	}
+}
+
+func handleExpand[T any](s *ExpandState[T], key keys.Key) (stop bool, err error) {
+	if s.expanded {
+		return handleSelect(&s.SelectState, key)
+	}
+
+	switch key.Code {
+	case keys.RuneKey:
+		if unicode.ToLower(key.Runes[0]) == expandHelpKey {
+			s.expanded = true
+			break
+		}
+		stop = s.handleShortcut(key.Runes[0])
+	case keys.Enter:
+		stop = s.hasDefault && !s.isDisabled(s.cursorPos)
+	}
+
+	return
+}
+
+func closeExpand[T any](s *ExpandState[T], err error) (summary string) {
+	if s.expanded {
+		clearLines(s.lines)
+		// Back to the prompt
+		cursor.Up()
+		cursor.Show()
+	}
+
+	if err != nil {
+		return err.Error()
+	}
+	return s.items[s.cursorPos].Label
+}
@@ -0,0 +1,78 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"testing"
+)
+
+func TestExpandHint(t *testing.T) {
+	tests := []struct {
+		name         string
+		choices      []Choice[string]
+		defaultIndex int
+		want         string
+	}{
+		{
+			name:         "default uppercase",
+			choices:      []Choice[string]{{Value: "yes", Key: 'y'}, {Value: "no", Key: 'n'}},
+			defaultIndex: 1,
+			want:         "(yNh) ",
+		},
+		{
+			name:         "keys not set are left out",
+			choices:      []Choice[string]{{Value: "yes", Key: 'y'}, {Value: "maybe"}, {Value: "no", Key: 'n'}},
+			defaultIndex: 0,
+			want:         "(Ynh) ",
+		},
+		{
+			name:         "reserved key is left out",
+			choices:      []Choice[string]{{Value: "yes", Key: 'y'}, {Value: "help", Key: 'H'}},
+			defaultIndex: 0,
+			want:         "(Yh) ",
+		},
+		{
+			name:         "no default",
+			choices:      []Choice[string]{{Value: "yes", Key: 'y'}, {Value: "no", Key: 'n'}},
+			defaultIndex: 5,
+			want:         "(ynh) ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewExpand("", tt.choices, tt.defaultIndex).inputPrompt; got != tt.want {
+				t.Errorf("hint = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandEnter(t *testing.T) {
+	choices := []Choice[string]{{Value: "yes", Key: 'y'}, {Value: "no", Key: 'n'}}
+
+	tests := []struct {
+		name         string
+		defaultIndex int
+		wantStop     bool
+	}{
+		{name: "chooses the default", defaultIndex: 1, wantStop: true},
+		{name: "does nothing without a default", defaultIndex: -1, wantStop: false},
+		{name: "does nothing with a default out of range", defaultIndex: 2, wantStop: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewExpand("", choices, tt.defaultIndex).state
+			stop, err := handleExpand(&s, keys.Key{Code: keys.Enter})
+			if err != nil {
+				t.Fatalf("handleExpand() error = %v", err)
+			}
+			if stop != tt.wantStop {
+				t.Errorf("stop = %v, want %v", stop, tt.wantStop)
+			}
+			if stop && s.Resolve() != choices[tt.defaultIndex].Value {
+				t.Errorf("Resolve() = %q, want %q", s.Resolve(), choices[tt.defaultIndex].Value)
+			}
+		})
+	}
+}
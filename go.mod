@@ -3,7 +3,8 @@ module github.com/liuuner/go-cli-input
 go 1.23.1
 
 require (
-	atomicgo.dev/keyboard v0.2.9 // indirect
-	github.com/containerd/console v1.0.3 // indirect
-	golang.org/x/sys v0.0.0-20220319134239-a9b59b0215f8 // indirect
+	atomicgo.dev/keyboard v0.2.9
+	github.com/containerd/console v1.0.3
 )
+
+require golang.org/x/sys v0.0.0-20220319134239-a9b59b0215f8 // indirect
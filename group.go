@@ -0,0 +1,14 @@
+package input
+
+import "strings"
+
+// Group is a set of items shown beneath a non-selectable heading
+type Group[T any] struct {
+	Name  string
+	Items []T
+}
+
+// Line rendered between two groups
+func separatorLine() string {
+	return "  " + col.Gray(strings.Repeat("─", 24))
+}
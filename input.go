@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"github.com/liuuner/go-cli-input/colors"
 	"github.com/liuuner/go-cli-input/cursor"
+	"sync"
 )
 
 type Input[T any] struct {
 	render            func(s *T, rerender bool)
 	handleInput       func(s *T, key keys.Key) (stop bool, err error)
 	close             func(s *T, err error) (summary string)
+	attach            func(s *T, update func(apply func()), submit func()) // hands the state a way to change and rerender itself, or to finish, from another goroutine
 	userPrompt        string
 	inputPrompt       string
 	promptString      string
@@ -39,9 +41,45 @@ func (i *Input[T]) Open() (state T, err error) {
 		}
 	}
 
+	var mu sync.Mutex
+	closed := false
+	submitted := false // the state asked to finish from another goroutine
+	if i.attach != nil {
+		update := func(apply func()) {
+			mu.Lock()
+			defer mu.Unlock()
+			// Late updates must not draw over the summary
+			if closed {
+				return
+			}
+			apply()
+			i.render(&i.state, true)
+		}
+		// Called within apply of update
+		submit := func() {
+			if closed || submitted {
+				return
+			}
+			submitted = true
+			// Listen only stops when a key is handled, so a key doing nothing is fed to it.
+			// It is sent from another goroutine as keys are handled while holding mu.
+			go keyboard.SimulateKeyPress(keys.Key{Code: keys.Null})
+		}
+		i.attach(&i.state, update, submit)
+	}
+
+	mu.Lock()
 	i.render(&i.state, false)
+	mu.Unlock()
 
 	err = keyboard.Listen(func(key keys.Key) (stop bool, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if submitted {
+			return true, nil
+		}
+
 		switch key.Code {
 		case keys.CtrlC:
 			return true, errors.New("terminated with SIGINT (130)")
@@ -54,6 +92,10 @@ func (i *Input[T]) Open() (state T, err error) {
 		return
 	})
 
+	mu.Lock()
+	defer mu.Unlock()
+	closed = true
+
 	summary := i.close(&i.state, err)
 
 	if i.hasPrompt {
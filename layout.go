@@ -0,0 +1,58 @@
+package input
+
+import (
+	"fmt"
+	"github.com/liuuner/go-cli-input/cursor"
+)
+
+type Layout int
+
+const (
+	LayoutVertical   Layout = iota // one item per line
+	LayoutHorizontal               // items next to each other on the prompt line
+	LayoutGrid                     // as many columns as the terminal is wide enough for, Select only
+)
+
+// Join cells separated by spaces into lines, the first line has firstWidth columns and the others width
+func wrapCells(cells []string, firstWidth, width int) []string {
+	var lines []string
+	line, available := "", firstWidth
+	for _, cell := range cells {
+		if line != "" && visibleLen(line)+1+visibleLen(cell) > available {
+			lines = append(lines, line)
+			// Indent the following lines
+			line, available = " ", width
+		}
+
+		if line == "" {
+			line = cell
+		} else {
+			line += " " + cell
+		}
+	}
+	return append(lines, line)
+}
+
+// Print lines starting at column of the prompt line over the block of prevLines lines rendered before.
+// The cursor is left at the end of the last line, returns the number of lines printed
+func drawInline(lines []string, prevLines int, column int) int {
+	if prevLines > 1 {
+		cursor.UpN(prevLines - 1)
+	}
+	cursor.StartOfLine()
+	cursor.MoveHorizontally(column)
+	cursor.ClearDown()
+
+	for index, line := range lines {
+		if index > 0 {
+			fmt.Print("\n\r")
+		}
+		fmt.Print(line)
+	}
+	return len(lines)
+}
+
+// Column behind the prompt line printed by Input.Open
+func promptWidth[T any](i *Input[T]) int {
+	return visibleLen(fmt.Sprintf("%s %s %s", i.promptString, i.userPrompt, i.inputPrompt))
+}
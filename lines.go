@@ -0,0 +1,38 @@
+package input
+
+import (
+	"fmt"
+	"github.com/liuuner/go-cli-input/cursor"
+)
+
+// Print lines over the block of prevLines lines rendered before.
+// The cursor is left at the end of the last line, returns the number of lines printed
+func drawLines(lines []string, prevLines int) int {
+	if prevLines > 1 {
+		// Move cursor to top
+		cursor.UpN(prevLines - 1)
+	}
+
+	for index, line := range lines {
+		cursor.StartOfLine()
+		cursor.ClearLine()
+		fmt.Print(line)
+		if index < len(lines)-1 {
+			// Adding a new line on the last line will move the cursor position out of range
+			fmt.Print("\n")
+		}
+	}
+
+	// Remove what is left of a previously longer block
+	cursor.ClearDown()
+	return len(lines)
+}
+
+// Clear a block of n lines, the cursor has to be on the last line of the block
+func clearLines(n int) {
+	cursor.ClearLine()
+	for i := 0; i < n-1; i++ {
+		cursor.Up()
+		cursor.ClearLine()
+	}
+}
@@ -0,0 +1,363 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Up and Down pressed within this time count as the key being held
+const holdInterval = 150 * time.Millisecond
+
+type NumberState[N Number] struct {
+	TextState
+	min, max       N
+	hasMin, hasMax bool
+	step           N
+	precision      int // decimals of a float, -1 for as many as needed
+	steppedAt      time.Time
+	held           int // number of steps in a row while Up or Down is held
+}
+
+type NumberOption[N Number] func(*Input[NumberState[N]])
+
+// NewNumber creates a Text accepting numbers only. Up and Down change the number by a step,
+// which grows while the key is held.
+func NewNumber[N Number](prompt string, opts ...NumberOption[N]) Input[NumberState[N]] {
+	i := newInput[NumberState[N]]()
+
+	state := NumberState[N]{
+		TextState: TextState{
+			defaultText: []rune{},
+			text:        []rune{},
+			position:    0,
+		},
+		step:      1,
+		precision: -1,
+	}
+
+	s := Input[NumberState[N]]{
+		render:            renderNumber[N],
+		handleInput:       handleNumber[N],
+		close:             closeNumber[N],
+		userPrompt:        prompt,
+		hasPrompt:         i.hasPrompt,
+		hasSummary:        i.hasSummary,
+		failedString:      i.failedString,
+		completedString:   i.completedString,
+		promptString:      i.promptString,
+		isLevelWithPrompt: true,
+		state:             state,
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return s
+}
+
+func renderNumber[N Number](s *NumberState[N], rerender bool) {
+	renderText(&s.TextState, rerender)
+}
+
+func handleNumber[N Number](s *NumberState[N], key keys.Key) (stop bool, err error) {
+	switch key.Code {
+	case keys.Up:
+		s.stepBy(1)
+		return false, nil
+	case keys.Down:
+		s.stepBy(-1)
+		return false, nil
+	case keys.Space:
+		return false, nil
+	case keys.Enter:
+		// Checked here instead of by a validator of the Text, which would have to point back at this state
+		if s.err = s.check(s.TextState.Resolve()); s.err != nil {
+			return false, nil
+		}
+	case keys.RuneKey:
+		if strings.Trim(string(key.Runes), s.allowedRunes()) != "" {
+			// Only numbers can be typed
+			return false, nil
+		}
+	}
+
+	return handleText(&s.TextState, key)
+}
+
+func closeNumber[N Number](s *NumberState[N], err error) (summary string) {
+	summary = closeText(&s.TextState, err)
+	if err == nil {
+		summary = s.format(s.Resolve())
+	}
+	return
+}
+
+// Resolve returns the number, rounded to the precision
+func (s *NumberState[N]) Resolve() N {
+	value, _ := s.parse(s.TextState.Resolve())
+	return value
+}
+
+// Change the number by dir steps, more of them while the key is held
+func (s *NumberState[N]) stepBy(dir int) {
+	if time.Since(s.steppedAt) < holdInterval {
+		s.held++
+	} else {
+		s.held = 0
+	}
+	s.steppedAt = time.Now()
+
+	steps := 1
+	if s.held >= 30 {
+		steps = 100
+	} else if s.held >= 10 {
+		steps = 10
+	}
+
+	value, err := s.parse(s.TextState.Resolve())
+	if err != nil {
+		value = 0
+	}
+	lowest, highest := minOf[N](), maxOf[N]()
+	if s.hasMin {
+		lowest = s.min
+	}
+	if s.hasMax {
+		highest = s.max
+	}
+	// Keep as many decimals as the number, the step and the bounds have, not the rounding errors of adding them
+	places := max(decimals(value), decimals(s.step), decimals(s.min), decimals(s.max))
+	value = s.clamp(stepNumber(value, s.step, dir*steps, lowest, highest))
+	if isFloat[N]() && s.precision < 0 {
+		value = roundNumber(value, places)
+	}
+
+	s.text = []rune(s.format(value))
+	s.position = len(s.text)
+	s.err = nil
+}
+
+// Validate the text, used as the validator of the embedded Text
+func (s *NumberState[N]) check(text string) error {
+	value, err := s.parse(text)
+	if err != nil {
+		return err
+	}
+	if s.hasMin && value < s.min {
+		return fmt.Errorf("must be at least %s", s.format(s.min))
+	}
+	if s.hasMax && value > s.max {
+		return fmt.Errorf("must be at most %s", s.format(s.max))
+	}
+	return nil
+}
+
+func (s *NumberState[N]) parse(text string) (N, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, errors.New("a number is required")
+	}
+
+	if isFloat[N]() {
+		f, err := strconv.ParseFloat(text, bitSize[N]())
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return 0, fmt.Errorf("%q is not a number", text)
+		}
+		if s.precision >= 0 {
+			scale := math.Pow10(s.precision)
+			f = math.Round(f*scale) / scale
+		}
+		return N(f), nil
+	}
+
+	if isUnsigned[N]() {
+		u, err := strconv.ParseUint(text, 10, 64)
+		if err != nil || uint64(N(u)) != u {
+			return 0, fmt.Errorf("%q is not a whole number in range", text)
+		}
+		return N(u), nil
+	}
+	i, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || int64(N(i)) != i {
+		return 0, fmt.Errorf("%q is not a whole number in range", text)
+	}
+	return N(i), nil
+}
+
+func (s *NumberState[N]) format(value N) string {
+	return formatNumber(value, s.precision)
+}
+
+// Format a number, floats with precision decimals or as many as needed if it is -1
+func formatNumber[N Number](value N, precision int) string {
+	if isFloat[N]() {
+		return strconv.FormatFloat(float64(value), 'f', precision, bitSize[N]())
+	}
+	return fmt.Sprint(value)
+}
+
+// Number of decimals of a float as it is formatted without rounding, 0 for integers
+func decimals[N Number](value N) int {
+	if !isFloat[N]() {
+		return 0
+	}
+	text := strconv.FormatFloat(float64(value), 'f', -1, bitSize[N]())
+	if dot := strings.IndexByte(text, '.'); dot >= 0 {
+		return len(text) - dot - 1
+	}
+	return 0
+}
+
+// Round a float to places decimals
+func roundNumber[N Number](value N, places int) N {
+	scale := math.Pow10(places)
+	return N(math.Round(float64(value)*scale) / scale)
+}
+
+// Add steps times step to value, or subtract them if steps is negative.
+// Stops at lowest or highest, the numbers are never subtracted from each other in N to not overflow it.
+func stepNumber[N Number](value, step N, steps int, lowest, highest N) N {
+	if step <= 0 {
+		return value
+	}
+	for range max(steps, -steps) {
+		if steps > 0 {
+			if !fitsStep(value, highest, step) {
+				return highest
+			}
+			value += step
+		} else {
+			if !fitsStep(lowest, value, step) {
+				return lowest
+			}
+			value -= step
+		}
+	}
+	return value
+}
+
+// Whether step fits between from and the higher number to
+func fitsStep[N Number](from, to, step N) bool {
+	if to < from {
+		return false
+	}
+	if isFloat[N]() {
+		return float64(to)-float64(from) >= float64(step)
+	}
+	if isUnsigned[N]() {
+		return uint64(to)-uint64(from) >= uint64(step)
+	}
+	// The difference of two int64 always fits into an uint64
+	return uint64(int64(to))-uint64(int64(from)) >= uint64(int64(step))
+}
+
+// Size of N in bits
+func bitSize[N Number]() int {
+	return reflect.TypeFor[N]().Bits()
+}
+
+// Lowest number N can hold
+func minOf[N Number]() N {
+	switch {
+	case isFloat[N]():
+		return -maxOf[N]()
+	case isUnsigned[N]():
+		return 0
+	}
+	return N(int64(-1) << (bitSize[N]() - 1))
+}
+
+// Highest number N can hold
+func maxOf[N Number]() N {
+	switch {
+	case isFloat[N]() && bitSize[N]() == 32:
+		f := math.MaxFloat32
+		return N(f)
+	case isFloat[N]():
+		f := math.MaxFloat64
+		return N(f)
+	case isUnsigned[N]():
+		return N(^uint64(0) >> (64 - bitSize[N]()))
+	}
+	return N(^uint64(0) >> (65 - bitSize[N]()))
+}
+
+func (s *NumberState[N]) clamp(value N) N {
+	if s.hasMin && value < s.min {
+		value = s.min
+	}
+	if s.hasMax && value > s.max {
+		value = s.max
+	}
+	return value
+}
+
+// Runes which can be typed into the number
+func (s *NumberState[N]) allowedRunes() string {
+	allowed := "0123456789"
+	if !isUnsigned[N]() {
+		allowed += "-"
+	}
+	if isFloat[N]() && s.precision != 0 {
+		allowed += "."
+	}
+	return allowed
+}
+
+func isFloat[N Number]() bool {
+	return N(1)/N(2) != 0
+}
+
+func isUnsigned[N Number]() bool {
+	zero := N(0)
+	return zero-1 > zero
+}
+
+// WithNumberMin refuses numbers lower than min
+func WithNumberMin[N Number](min N) NumberOption[N] {
+	return func(s *Input[NumberState[N]]) {
+		s.state.min, s.state.hasMin = min, true
+	}
+}
+
+// WithNumberMax refuses numbers higher than max
+func WithNumberMax[N Number](max N) NumberOption[N] {
+	return func(s *Input[NumberState[N]]) {
+		s.state.max, s.state.hasMax = max, true
+	}
+}
+
+// WithNumberStep sets what Up and Down add and subtract, 1 by default
+func WithNumberStep[N Number](step N) NumberOption[N] {
+	return func(s *Input[NumberState[N]]) {
+		s.state.step = step
+	}
+}
+
+// WithNumberPrecision rounds floats to decimals places, the summary always showing that many
+func WithNumberPrecision[N Number](decimals int) NumberOption[N] {
+	return func(s *Input[NumberState[N]]) {
+		s.state.precision = decimals
+	}
+}
+
+// WithNumberValue sets the number the input starts with
+func WithNumberValue[N Number](value N) NumberOption[N] {
+	return func(s *Input[NumberState[N]]) {
+		s.state.text = []rune(s.state.format(value))
+		s.state.position = len(s.state.text)
+	}
+}
@@ -0,0 +1,144 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"testing"
+	"time"
+)
+
+func TestNumberStepStaysInRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		state NumberState[int8]
+		text  string
+		dir   int
+		held  int
+		want  string
+	}{
+		{name: "up", state: NewNumber[int8]("").state, text: "5", dir: 1, want: "6"},
+		{name: "down", state: NewNumber[int8]("").state, text: "5", dir: -1, want: "4"},
+		{name: "held up stops at the type maximum", state: NewNumber[int8]("").state, text: "100", dir: 1, held: 40, want: "127"},
+		{name: "held down stops at the type minimum", state: NewNumber[int8]("").state, text: "-100", dir: -1, held: 40, want: "-128"},
+		{name: "held across the whole range", state: NewNumber[int8]("", WithNumberMin[int8](-100), WithNumberMax[int8](100)).state, text: "-100", dir: 1, held: 40, want: "0"},
+		{name: "stops at max", state: NewNumber[int8]("", WithNumberMax[int8](120)).state, text: "119", dir: 1, held: 40, want: "120"},
+		{name: "above max moves to max", state: NewNumber[int8]("", WithNumberMax[int8](10)).state, text: "50", dir: 1, want: "10"},
+		{name: "stops at min", state: NewNumber[int8]("", WithNumberMin[int8](-120)).state, text: "-119", dir: -1, held: 15, want: "-120"},
+		{name: "empty starts at zero", state: NewNumber[int8]("").state, text: "", dir: 1, want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.state
+			s.text = []rune(tt.text)
+			s.held = tt.held
+			if tt.held > 0 {
+				// Within the hold interval the step grows
+				s.held--
+				s.steppedAt = time.Now()
+			}
+			s.stepBy(tt.dir)
+			if got := string(s.text); got != tt.want {
+				t.Errorf("text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberUnsignedStaysInRange(t *testing.T) {
+	s := NewNumber[uint8]("").state
+	s.text = []rune("3")
+	s.held = 39
+	s.steppedAt = time.Now()
+	s.stepBy(-1)
+	if got := string(s.text); got != "0" {
+		t.Errorf("text = %q, want \"0\"", got)
+	}
+
+	s.text = []rune("200")
+	s.held = 39
+	s.steppedAt = time.Now()
+	s.stepBy(1)
+	if got := string(s.text); got != "255" {
+		t.Errorf("text = %q, want \"255\"", got)
+	}
+}
+
+func TestNumberFloat32Format(t *testing.T) {
+	s := NewNumber[float32]("").state
+	s.text = []rune("0.1")
+	if got := closeNumber(&s, nil); got != "0.1" {
+		t.Errorf("summary = %q, want \"0.1\"", got)
+	}
+
+	if _, err := handleNumber(&s, keys.Key{Code: keys.Up}); err != nil {
+		t.Fatalf("handleNumber() error = %v", err)
+	}
+	if got := string(s.text); got != "1.1" {
+		t.Errorf("text = %q, want \"1.1\"", got)
+	}
+}
+
+func TestNumberCheck(t *testing.T) {
+	s := NewNumber[int8]("", WithNumberMin[int8](-10), WithNumberMax[int8](10)).state
+	tests := []struct {
+		text    string
+		wantErr bool
+	}{
+		{text: "0"},
+		{text: "-10"},
+		{text: "10"},
+		{text: "11", wantErr: true},
+		{text: "-11", wantErr: true},
+		{text: "200", wantErr: true},
+		{text: "", wantErr: true},
+		{text: "1.5", wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := s.check(tt.text); (err != nil) != tt.wantErr {
+			t.Errorf("check(%q) error = %v, want error %v", tt.text, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNumberFloatStepRounds(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		dir  int
+		want string
+	}{
+		{name: "up", text: "0.2", dir: 1, want: "0.3"},
+		{name: "down", text: "0.3", dir: -1, want: "0.2"},
+		{name: "keeps the decimals of the number", text: "0.25", dir: 1, want: "0.35"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewNumber[float64]("", WithNumberStep[float64](0.1)).state
+			s.text = []rune(tt.text)
+			s.stepBy(tt.dir)
+			if got := string(s.text); got != tt.want {
+				t.Errorf("text = %q, want %q", got, tt.want)
+			}
+			if got := closeNumber(&s, nil); got != tt.want {
+				t.Errorf("summary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumberEnterChecksBounds(t *testing.T) {
+	// A copy of the state, as Open works on, still checks its own bounds
+	s := NewNumber[int]("", WithNumberMax[int](10)).state
+	s.text = []rune("11")
+	if stop, _ := handleNumber(&s, keys.Key{Code: keys.Enter}); stop {
+		t.Error("handleNumber() stopped above max")
+	}
+	if s.err == nil {
+		t.Error("err = nil, want an error above max")
+	}
+
+	s.text = []rune("10")
+	if stop, _ := handleNumber(&s, keys.Key{Code: keys.Enter}); !stop {
+		t.Error("handleNumber() did not stop at max")
+	}
+}
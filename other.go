@@ -0,0 +1,60 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"github.com/liuuner/go-cli-input/cursor"
+)
+
+// Turn the "Other…" item into a text field
+func (s *SelectState[T]) startEditing() {
+	s.editing = true
+	cursor.Show()
+}
+
+// Handle a key while the "Other…" item is edited
+func (s *SelectState[T]) handleOther(key keys.Key) (stop bool, err error) {
+	switch key.Code {
+	case keys.Up, keys.Down:
+		// Leave the text field and move on through the list
+		s.editing = false
+		s.otherErr = nil
+		cursor.Hide()
+		return handleSelect(s, key)
+	case keys.Enter:
+		value, parseErr := s.parseOther(s.other.Resolve())
+		if parseErr != nil {
+			s.otherErr = parseErr
+			return false, nil
+		}
+		s.otherValue = value
+		return true, nil
+	}
+
+	// The error stays until the text changes
+	s.otherErr = nil
+	return handleText(&s.other, key)
+}
+
+// Line of the "Other…" item turned into a text field
+func (s *SelectState[T]) otherLine(prefix string) string {
+	return prefix + s.items[s.cursorPos].Label + " " + string(s.other.text)
+}
+
+// Place the terminal cursor inside the text field of the "Other…" item,
+// which is linesBelow lines above the end of what was rendered
+func (s *SelectState[T]) placeOtherCursor(prefix string, linesBelow int) {
+	if linesBelow > 0 {
+		cursor.UpN(linesBelow)
+	}
+	cursor.StartOfLine()
+	cursor.MoveHorizontally(visibleLen(prefix+s.items[s.cursorPos].Label+" ") + s.other.position)
+	s.linesBelowCursor = linesBelow
+}
+
+// Move the terminal cursor back to the end of what was rendered
+func (s *SelectState[T]) restoreCursor() {
+	if s.linesBelowCursor > 0 {
+		cursor.DownN(s.linesBelowCursor)
+	}
+	s.linesBelowCursor = 0
+}
@@ -0,0 +1,123 @@
+package input
+
+import "strings"
+
+type PreviewPosition int
+
+const (
+	PreviewBelow PreviewPosition = iota
+	PreviewBeside
+)
+
+// Maximum number of lines of a preview which are shown
+const maxPreviewLines = 10
+
+// previewPane shows a panel for the highlighted item of a list.
+// Previews are computed in the background so they don't block navigation.
+type previewPane[T any] struct {
+	preview  func(T) string
+	position PreviewPosition
+	update   func(apply func())
+	previews map[int]string
+	pending  map[int]bool
+	cleared  int // number of times the previews were cleared, to drop previews computed before
+}
+
+func (p *previewPane[T]) attach(update func(apply func())) {
+	p.update = update
+}
+
+// Add the preview of the item at index to the lines of the list
+func (p *previewPane[T]) render(lines []string, index int, value T) []string {
+	if p.preview == nil {
+		return lines
+	}
+
+	previewText, ok := p.get(index, value)
+	if !ok {
+		previewText = col.Gray("…")
+	}
+	previewLines := strings.Split(strings.TrimRight(previewText, "\n"), "\n")
+	if len(previewLines) > maxPreviewLines {
+		previewLines = previewLines[:maxPreviewLines]
+	}
+
+	width := terminalWidth()
+	if p.position == PreviewBeside {
+		listWidth := 0
+		for _, line := range lines {
+			listWidth = max(listWidth, visibleLen(line))
+		}
+
+		combined := make([]string, max(len(lines), len(previewLines)))
+		for i := range combined {
+			line := ""
+			if i < len(lines) {
+				line = lines[i]
+			}
+			if i < len(previewLines) {
+				line = padRight(line, listWidth) + col.Gray("  │ ") + previewLines[i]
+			}
+			combined[i] = truncate(line, width-1)
+		}
+		return combined
+	}
+
+	for _, line := range previewLines {
+		lines = append(lines, truncate(col.Gray("  │ ")+line, width-1))
+	}
+	return lines
+}
+
+// The preview of the item at index if it is computed already, otherwise its computation is started
+func (p *previewPane[T]) get(index int, value T) (string, bool) {
+	if previewText, ok := p.previews[index]; ok {
+		return previewText, true
+	}
+	if p.previews == nil {
+		p.previews = map[int]string{}
+		p.pending = map[int]bool{}
+	}
+
+	if p.update == nil {
+		p.previews[index] = p.preview(value)
+		return p.previews[index], true
+	}
+
+	if !p.pending[index] {
+		p.pending[index] = true
+		cleared := p.cleared
+		go func() {
+			previewText := p.preview(value)
+			p.update(func() {
+				// The item at index may have changed meanwhile
+				if p.cleared != cleared {
+					return
+				}
+				p.previews[index] = previewText
+				delete(p.pending, index)
+			})
+		}()
+	}
+	return "", false
+}
+
+// Forget all previews, for when items change their index
+func (p *previewPane[T]) clear() {
+	p.previews = nil
+	p.pending = nil
+	p.cleared++
+}
+
+// Lines of a description of the highlighted item
+func descriptionLines(description string) []string {
+	if description == "" {
+		return nil
+	}
+
+	var lines []string
+	for _, line := range strings.Split(description, "\n") {
+		lines = append(lines, "      "+col.Gray(line))
+	}
+	return lines
+}
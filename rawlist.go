@@ -0,0 +1,108 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"fmt"
+	"github.com/liuuner/go-cli-input/cursor"
+	"strconv"
+	"strings"
+)
+
+type RawListState[T any] struct {
+	TextState // the typed number
+	items     []T
+	GetName   func(T) string
+	message   string // shown next to the number until the next key
+}
+
+// NewRawList prints a numbered list once and lets the user type the number of an item.
+// Nothing but the typed number is redrawn, which suits screen readers and slow terminals.
+func NewRawList[T any](prompt string, items []T, getName func(T) string) Input[RawListState[T]] {
+	i := newInput[RawListState[T]]()
+
+	state := RawListState[T]{
+		TextState: TextState{
+			defaultText: []rune{},
+			text:        []rune{},
+			position:    0,
+		},
+		items:   items,
+		GetName: getName,
+	}
+
+	return Input[RawListState[T]]{
+		render:          renderRawList[T],
+		handleInput:     handleRawList[T],
+		close:           closeRawList[T],
+		userPrompt:      prompt,
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+}
+
+func renderRawList[T any](s *RawListState[T], rerender bool) {
+	if !rerender {
+		for index, item := range s.items {
+			fmt.Printf("\r  %s %s\r\n", col.Gray(index+1, ")"), s.GetName(item))
+		}
+		fmt.Print("\r  Answer: ")
+	}
+
+	renderText(&s.TextState, rerender)
+
+	if s.message != "" {
+		// Show the message behind the text and return to the position within the text
+		message := "  " + col.Red(s.message)
+		cursor.MoveHorizontally(len(s.text) - s.position)
+		fmt.Print(message)
+		cursor.MoveHorizontally(-visibleLen(message) - (len(s.text) - s.position))
+	}
+}
+
+func handleRawList[T any](s *RawListState[T], key keys.Key) (stop bool, err error) {
+	s.message = ""
+
+	if key.Code == keys.RuneKey && strings.Trim(string(key.Runes), "0123456789") != "" {
+		// Only numbers can be typed
+		return false, nil
+	}
+
+	stop, err = handleText(&s.TextState, key)
+	if stop {
+		if _, ok := s.chosenIndex(); !ok {
+			s.message = fmt.Sprintf("Enter a number between 1 and %d", len(s.items))
+			stop = false
+		}
+	}
+
+	return
+}
+
+func closeRawList[T any](s *RawListState[T], err error) (summary string) {
+	closeText(&s.TextState, err)
+	// Remove the list, the answer line being the last line of it
+	clearLines(len(s.items) + 1)
+
+	if err != nil {
+		return err.Error()
+	}
+	return s.GetName(s.Resolve())
+}
+
+func (s *RawListState[T]) Resolve() T {
+	index, _ := s.chosenIndex()
+	return s.items[index]
+}
+
+// Index of the item whose number was typed
+func (s *RawListState[T]) chosenIndex() (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(string(s.text)))
+	if err != nil || n < 1 || n > len(s.items) {
+		return 0, false
+	}
+	return n - 1, true
+}
@@ -2,33 +2,126 @@ package input
 
 import (
 	"atomicgo.dev/keyboard/keys"
-	"fmt"
 	"github.com/liuuner/go-cli-input/colors"
 	"github.com/liuuner/go-cli-input/cursor"
+	"slices"
+	"strings"
+	"time"
 )
 
+// Number of items left below the cursor before the next page is fetched
+const prefetchDistance = 3
+
+type SelectOption[T any] func(*Input[SelectState[T]])
+
+// selectItem is a line of a Select, either an item or a group header
+type selectItem[T any] struct {
+	Choice[T]
+	isHeader bool
+	header   string // name of the group
+	isOther  bool   // turns into a text field when chosen
+}
+
 type SelectState[T any] struct {
-	items      []T
-	GetName    func(T) string
-	GetColor   func(T) colors.Formatter
-	cursorRune rune
-	cursorPos  int
+	items          []selectItem[T]
+	GetName        func(T) string
+	GetColor       func(T) colors.Formatter
+	GetDescription func(T) string // text shown beneath the highlighted item
+	cursorRune     rune
+	cursorPos      int
+	disabled       func(T) (bool, string) // reports whether an item can't be chosen and why
+	source         ItemSource[T]
+	pageSize       int
+	fetched        bool // the first page was fetched
+	exhausted      bool // the source has no more items
+	err            error
+	pane           previewPane[T]
+	lines          int // number of lines rendered last time
+
+	numberShortcuts bool
+	shortcutsChoose bool // a shortcut chooses its item instead of only moving the cursor to it
+	typeAhead       bool
+	typed           string // what was typed for the type-ahead search
+	typedAt         time.Time
+
+	layout      Layout
+	promptWidth int // column the items start at in the horizontal layout
+
+	parseOther       func(string) (T, error)
+	other            TextState // text typed into the "Other…" item
+	editing          bool      // the "Other…" item is edited
+	otherErr         error
+	otherValue       T
+	loaded           int                     // number of items fetched from the source
+	pendingCursor    []func(*SelectState[T]) // cursor options waiting for the first page
+	linesBelowCursor int                     // lines rendered below the text cursor of the "Other…" item
 }
 
-func NewSelect[T any](prompt string, items []T, getName func(T) string) Input[SelectState[T]] {
-	i := newInput[SelectState[T]]()
+func NewSelect[T any](prompt string, items []T, getName func(T) string, opts ...SelectOption[T]) Input[SelectState[T]] {
+	s := NewSelectChoices(prompt, choicesOf(items, getName), opts...)
+	s.state.GetName = getName
+	return s
+}
+
+// NewSelectChoices creates a Select over choices, which carry their own label, hint, shortcut and disabled state
+func NewSelectChoices[T any](prompt string, choices []Choice[T], opts ...SelectOption[T]) Input[SelectState[T]] {
+	selectItems := make([]selectItem[T], len(choices))
+	for i, choice := range choices {
+		selectItems[i] = selectItem[T]{Choice: choice}
+	}
+
+	state := SelectState[T]{
+		items:      selectItems,
+		cursorRune: '❯',
+		cursorPos:  0,
+	}
+
+	return newSelect(prompt, state, opts)
+}
+
+// NewSelectGroups creates a Select whose items are listed beneath the name of their group
+func NewSelectGroups[T any](prompt string, groups []Group[T], getName func(T) string, opts ...SelectOption[T]) Input[SelectState[T]] {
+	var selectItems []selectItem[T]
+	for _, group := range groups {
+		selectItems = append(selectItems, selectItem[T]{isHeader: true, header: group.Name})
+		for _, choice := range choicesOf(group.Items, getName) {
+			selectItems = append(selectItems, selectItem[T]{Choice: choice})
+		}
+	}
+
+	state := SelectState[T]{
+		items:      selectItems,
+		cursorRune: '❯',
+		cursorPos:  0,
+		GetName:    getName,
+	}
+
+	return newSelect(prompt, state, opts)
+}
 
+// NewSelectSource creates a Select which fetches its items from source pageSize items at a time.
+// The first page is fetched when the Select is opened, the next one once the cursor gets close to the end of the loaded items.
+func NewSelectSource[T any](prompt string, source ItemSource[T], pageSize int, getName func(T) string, opts ...SelectOption[T]) Input[SelectState[T]] {
 	state := SelectState[T]{
-		items:      items,
+		items:      []selectItem[T]{},
 		cursorRune: '❯',
 		cursorPos:  0,
 		GetName:    getName,
+		source:     source,
+		pageSize:   max(pageSize, 1),
 	}
 
-	return Input[SelectState[T]]{
+	return newSelect(prompt, state, opts)
+}
+
+func newSelect[T any](prompt string, state SelectState[T], opts []SelectOption[T]) Input[SelectState[T]] {
+	i := newInput[SelectState[T]]()
+
+	s := Input[SelectState[T]]{
 		render:          renderSelect[T],
 		handleInput:     handleSelect[T],
 		close:           closeSelect[T],
+		attach:          attachSelect[T],
 		userPrompt:      prompt,
 		inputPrompt:     "› - Use arrow-keys. Return to submit.",
 		hasPrompt:       i.hasPrompt,
@@ -38,77 +131,531 @@ func NewSelect[T any](prompt string, items []T, getName func(T) string) Input[Se
 		promptString:    i.promptString,
 		state:           state,
 	}
+
+	inputPrompt := s.inputPrompt
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	if s.state.parseOther != nil && s.state.layout != LayoutVertical {
+		// The text field of "Other…" only fits into the vertical layout
+		s.state.layout = LayoutVertical
+		s.isLevelWithPrompt = false
+		s.inputPrompt = inputPrompt
+	}
+
+	if s.state.isDisabled(s.state.cursorPos) {
+		s.state.move(1)
+	}
+
+	return s
 }
 
 func renderSelect[T any](s *SelectState[T], rerender bool) {
-	if rerender {
-		// Move cursor to top
-		cursor.UpN(len(s.items) - 1)
-	} else {
+	if !rerender {
 		cursor.Hide()
 	}
+	s.restoreCursor()
+	s.fetchFirstPage()
 
+	switch s.layout {
+	case LayoutHorizontal:
+		renderSelectHorizontal(s)
+		return
+	case LayoutGrid:
+		renderSelectGrid(s)
+		return
+	}
+
+	lines := make([]string, 0, len(s.items)+1)
+	otherLine := -1
 	for index, item := range s.items {
-		var newline = "\n"
-		if index == len(s.items)-1 {
-			// Adding a new line on the last option will move the cursor position out of range
-			// For out redrawing
-			newline = ""
+		if item.isHeader {
+			if index > 0 {
+				lines = append(lines, separatorLine())
+			}
+			if item.header != "" {
+				lines = append(lines, "  "+col.Bold(item.header))
+			}
+			continue
 		}
 
-		menuItemText := s.GetName(item)
-		if s.GetColor != nil {
-			menuItemText = s.GetColor(item)(menuItemText)
-		}
 		cursorString := "   "
 		if index == s.cursorPos { // for color or other effects
 			cursorString = col.Cyan(string(s.cursorRune), "  ")
-			menuItemText = col.Underline(menuItemText)
 		}
 
-		fmt.Printf("\r%s %s%s", cursorString, menuItemText, newline)
+		if index == s.cursorPos && s.editing {
+			otherLine = len(lines)
+			lines = append(lines, s.otherLine(cursorString+" "+s.shortcutLabel(index)))
+			if s.otherErr != nil {
+				lines = append(lines, "      "+col.Red(s.otherErr.Error()))
+			}
+			continue
+		}
+
+		lines = append(lines, cursorString+" "+s.shortcutLabel(index)+s.itemText(index))
+		if index == s.cursorPos && s.GetDescription != nil && !item.isOther {
+			lines = append(lines, descriptionLines(s.GetDescription(item.Value))...)
+		}
+	}
+
+	if s.err != nil {
+		lines = append(lines, "    "+col.Red(s.err.Error()))
+	} else if s.hasMore() {
+		lines = append(lines, "    "+col.Gray("…"))
+	}
+
+	// The "Other…" item has no value to preview
+	if s.cursorPos < len(s.items) && !s.items[s.cursorPos].isHeader && !s.items[s.cursorPos].isOther {
+		lines = s.pane.render(lines, s.cursorPos, s.items[s.cursorPos].Value)
+	}
+
+	s.lines = drawLines(lines, s.lines)
+
+	if otherLine >= 0 {
+		s.placeOtherCursor(col.Cyan(string(s.cursorRune), "  ")+" "+s.shortcutLabel(s.cursorPos), len(lines)-1-otherLine)
+	}
+}
+
+// Render the items next to each other behind the prompt, wrapping only when they don't fit
+func renderSelectHorizontal[T any](s *SelectState[T]) {
+	cells := make([]string, 0, len(s.items)+1)
+	for index, item := range s.items {
+		if item.isHeader {
+			continue
+		}
+
+		cursorString := " "
+		if index == s.cursorPos {
+			cursorString = col.Cyan(string(s.cursorRune))
+		}
+		cells = append(cells, cursorString+s.itemText(index))
+	}
+
+	if s.err != nil {
+		cells = append(cells, col.Red(s.err.Error()))
+	} else if s.hasMore() {
+		cells = append(cells, col.Gray("…"))
+	}
+
+	width := terminalWidth() - 1
+	s.lines = drawInline(wrapCells(cells, width-s.promptWidth-1, width), s.lines, s.promptWidth+1)
+}
+
+// Render the items row by row in as many columns as fit into the terminal
+func renderSelectGrid[T any](s *SelectState[T]) {
+	cells := s.gridCells()
+	columns := s.gridColumns()
+	cellWidth := s.gridCellWidth()
+
+	lines := make([]string, 0, len(cells)/columns+2)
+	for start := 0; start < len(cells); start += columns {
+		row := make([]string, 0, columns)
+		for _, index := range cells[start:min(start+columns, len(cells))] {
+			cursorString := "  "
+			if index == s.cursorPos {
+				cursorString = col.Cyan(string(s.cursorRune), " ")
+			}
+			row = append(row, padRight(cursorString+s.itemText(index), cellWidth))
+		}
+		lines = append(lines, " "+strings.TrimRight(strings.Join(row, ""), " "))
+	}
+
+	if s.err != nil {
+		lines = append(lines, "    "+col.Red(s.err.Error()))
+	} else if s.hasMore() {
+		lines = append(lines, "    "+col.Gray("…"))
+	}
+
+	s.lines = drawLines(lines, s.lines)
+}
+
+// Move the cursor through the grid, left and right by one item, up and down by one row
+func (s *SelectState[T]) moveGrid(code keys.KeyCode) {
+	delta := 1
+	switch code {
+	case keys.Left:
+		delta = -1
+	case keys.Up:
+		delta = -s.gridColumns()
+	case keys.Down:
+		delta = s.gridColumns()
+	}
+
+	cells := s.gridCells()
+	if delta > 0 && slices.Index(cells, s.cursorPos)+delta >= len(cells) && s.hasMore() {
+		s.fetchMore()
+		cells = s.gridCells()
+	}
+
+	for next := slices.Index(cells, s.cursorPos) + delta; next >= 0 && next < len(cells); next += delta {
+		if !s.isDisabled(cells[next]) {
+			s.cursorPos = cells[next]
+			return
+		}
+	}
+}
+
+// Indices of the items shown in the grid, which has no group headers
+func (s *SelectState[T]) gridCells() []int {
+	cells := make([]int, 0, len(s.items))
+	for index, item := range s.items {
+		if !item.isHeader {
+			cells = append(cells, index)
+		}
+	}
+	return cells
+}
+
+// Width of a grid cell, the widest item and a gap
+func (s *SelectState[T]) gridCellWidth() int {
+	width := 0
+	for _, index := range s.gridCells() {
+		width = max(width, visibleLen(s.itemText(index)))
+	}
+	return width + 4 // cursor and gap
+}
+
+// Number of columns fitting into the terminal, recomputed every time to follow resizes
+func (s *SelectState[T]) gridColumns() int {
+	return max((terminalWidth()-2)/s.gridCellWidth(), 1)
+}
+
+// Label of the item at index with its color, hint and disabled state
+func (s *SelectState[T]) itemText(index int) string {
+	item := s.items[index]
+
+	menuItemText := item.Label
+	if disabled, reason := s.disabledAt(index); disabled {
+		menuItemText = col.Dim(menuItemText)
+		if reason != "" {
+			menuItemText += " " + col.Gray("(", reason, ")")
+		}
+	} else if s.GetColor != nil && !item.isOther {
+		menuItemText = s.GetColor(item.Value)(menuItemText)
+	}
+	if item.Hint != "" {
+		menuItemText += " " + col.Gray(item.Hint)
+	}
+	if index == s.cursorPos {
+		menuItemText = col.Underline(menuItemText)
 	}
+	return menuItemText
 }
 
 func handleSelect[T any](s *SelectState[T], key keys.Key) (stop bool, err error) {
+	if s.err != nil {
+		return true, s.err
+	}
+	if s.editing {
+		return s.handleOther(key)
+	}
+
+	if s.layout == LayoutHorizontal {
+		// Left and Right move the cursor, Home and End take over jumping to the first and last item
+		switch key.Code {
+		case keys.Left:
+			key.Code = keys.Up
+		case keys.Right:
+			key.Code = keys.Down
+		case keys.Home:
+			key.Code = keys.Left
+		case keys.End:
+			key.Code = keys.Right
+		}
+	}
+
+	if s.layout == LayoutGrid {
+		// The arrow keys move through the grid in two dimensions, Home and End jump to the first and last item
+		switch key.Code {
+		case keys.Left, keys.Right, keys.Up, keys.Down:
+			s.moveGrid(key.Code)
+			key.Code = keys.Null
+		case keys.Home:
+			key.Code = keys.Left
+		case keys.End:
+			key.Code = keys.Right
+		}
+	}
+
 	switch key.Code {
 	case keys.Left:
 		s.cursorPos = 0
+		if s.isDisabled(s.cursorPos) {
+			s.move(1)
+		}
 	case keys.Right:
-		s.cursorPos = len(s.items) - 1
+		s.cursorPos = max(len(s.items)-1, 0)
+		if s.isDisabled(s.cursorPos) {
+			s.move(-1)
+		}
 	case keys.Up:
-		s.cursorPos--
-		s.keepPosInBoundaries()
+		s.move(-1)
 	case keys.Down:
-		s.cursorPos++
-		s.keepPosInBoundaries()
+		s.move(1)
 	case keys.Enter:
-		stop, err = true, nil
+		if len(s.items) > 0 && s.items[s.cursorPos].isOther {
+			s.startEditing()
+		} else if len(s.items) > 0 && !s.isDisabled(s.cursorPos) {
+			stop, err = true, nil
+		}
+	case keys.RuneKey:
+		stop = s.handleShortcut(key.Runes[0])
+	}
+
+	// A chosen item must not be lost to a failing fetch
+	if !stop && s.cursorPos >= len(s.items)-prefetchDistance {
+		s.fetchMore()
+	}
+	if s.err != nil {
+		return true, s.err
 	}
 
 	return
 }
 
-func closeSelect[T any](s *SelectState[T], err error) (summary string) {
-	cursor.ClearLine()
-	for i := 0; i < len(s.items)-1; i++ {
-		cursor.Up()
-		cursor.ClearLine()
-	}
+func attachSelect[T any](s *SelectState[T], update func(apply func()), _ func()) {
+	s.pane.attach(update)
+}
 
-	summary = s.GetName(s.items[s.cursorPos])
+func closeSelect[T any](s *SelectState[T], err error) (summary string) {
+	s.restoreCursor()
+	clearLines(s.lines)
 
 	if err != nil {
 		summary = err.Error()
+	} else if s.editing {
+		summary = s.other.Resolve()
+	} else {
+		summary = s.items[s.cursorPos].Label
 	}
+
 	cursor.Show()
 	return
 }
 
 func (s *SelectState[T]) Resolve() T {
-	return s.items[s.cursorPos]
+	if s.editing {
+		return s.otherValue
+	}
+	return s.items[s.cursorPos].Value
 }
 
 func (s *SelectState[T]) keepPosInBoundaries() {
+	if len(s.items) == 0 {
+		s.cursorPos = 0
+		return
+	}
 	s.cursorPos = (s.cursorPos + len(s.items)) % len(s.items)
 }
+
+// Move the cursor by dir to the next item which is neither disabled nor a header
+func (s *SelectState[T]) move(dir int) {
+	start := s.cursorPos
+	for i := 0; i < len(s.items); i++ {
+		if dir > 0 && s.cursorPos == len(s.items)-1 {
+			// Load the next page instead of wrapping around
+			s.fetchMore()
+		}
+		s.cursorPos += dir
+		s.keepPosInBoundaries()
+		if !s.isDisabled(s.cursorPos) {
+			return
+		}
+	}
+	s.cursorPos = start
+}
+
+func (s *SelectState[T]) isDisabled(index int) bool {
+	if index < len(s.items) && s.items[index].isHeader {
+		return true
+	}
+	disabled, _ := s.disabledAt(index)
+	return disabled
+}
+
+// Whether the item at index can't be chosen and the reason why
+func (s *SelectState[T]) disabledAt(index int) (bool, string) {
+	if index >= len(s.items) || s.items[index].isHeader {
+		return false, ""
+	}
+	if item := s.items[index]; item.Disabled {
+		return true, item.DisabledReason
+	}
+	if s.disabled == nil || s.items[index].isOther {
+		return false, ""
+	}
+	return s.disabled(s.items[index].Value)
+}
+
+// Index of the n-th item not counting group headers, -1 if there is none
+func (s *SelectState[T]) itemIndex(n int) int {
+	for index, item := range s.items {
+		if item.isHeader {
+			continue
+		}
+		if n == 0 {
+			return index
+		}
+		n--
+	}
+	return -1
+}
+
+// Whether the source can provide more items than loaded so far
+func (s *SelectState[T]) hasMore() bool {
+	return s.source != nil && !s.exhausted && s.err == nil
+}
+
+// Load the next page of items from the source
+// Fetch the first page of a source, which is left to the first render to not block the constructor
+func (s *SelectState[T]) fetchFirstPage() {
+	if s.source == nil || s.fetched {
+		return
+	}
+	s.fetched = true
+	s.fetchMore()
+
+	for _, place := range s.pendingCursor {
+		place(s)
+	}
+	s.pendingCursor = nil
+	if s.isDisabled(s.cursorPos) {
+		s.move(1)
+	}
+}
+
+// Place the cursor now, or once the first page is fetched
+func (s *SelectState[T]) placeCursor(place func(*SelectState[T])) {
+	if s.source != nil && !s.fetched {
+		s.pendingCursor = append(s.pendingCursor, place)
+		return
+	}
+	place(s)
+}
+
+func (s *SelectState[T]) fetchMore() {
+	if !s.hasMore() {
+		return
+	}
+
+	items, total, err := s.source.Fetch(s.loaded, s.pageSize)
+	if err != nil {
+		s.err = err
+		return
+	}
+
+	// Fetched items go before a trailing "Other…" item
+	end := len(s.items)
+	if end > 0 && s.items[end-1].isOther {
+		end--
+	}
+	fetched := make([]selectItem[T], len(items))
+	for i, choice := range choicesOf(items, s.GetName) {
+		fetched[i] = selectItem[T]{Choice: choice}
+	}
+	if end < len(s.items) && len(fetched) > 0 {
+		// Previews are kept by index, which changes for the items moved down
+		s.pane.clear()
+	}
+	s.items = slices.Insert(s.items, end, fetched...)
+
+	s.loaded += len(items)
+	if len(items) < s.pageSize || (total != UnknownTotal && s.loaded >= total) {
+		s.exhausted = true
+	}
+}
+
+// WithSelectCursor sets the initially highlighted item by its index, group headers are not counted
+func WithSelectCursor[T any](index int) SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.placeCursor(func(s *SelectState[T]) {
+			if pos := s.itemIndex(index); pos >= 0 {
+				s.cursorPos = pos
+			}
+		})
+	}
+}
+
+// WithSelectCursorFunc highlights the first item matching initially
+func WithSelectCursorFunc[T any](match func(T) bool) SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.placeCursor(func(s *SelectState[T]) {
+			for index, item := range s.items {
+				if !item.isHeader && match(item.Value) {
+					s.cursorPos = index
+					return
+				}
+			}
+		})
+	}
+}
+
+// WithSelectDisabled marks items for which disabled returns true as not selectable,
+// the optional reason is shown next to the item
+func WithSelectDisabled[T any](disabled func(T) (bool, string)) SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.disabled = disabled
+	}
+}
+
+// WithSelectDescription shows the text returned by getDescription dimmed beneath the highlighted item
+func WithSelectDescription[T any](getDescription func(T) string) SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.GetDescription = getDescription
+	}
+}
+
+// WithSelectPreview shows a panel rendered by preview for the highlighted item below or beside the list.
+// preview is called in the background, so it may take its time.
+func WithSelectPreview[T any](preview func(T) string, position PreviewPosition) SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.pane.preview = preview
+		s.state.pane.position = position
+	}
+}
+
+// WithNumberShortcuts numbers the first nine items, pressing a number moves the cursor to its item
+func WithNumberShortcuts[T any]() SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.numberShortcuts = true
+	}
+}
+
+// WithShortcutsChoose makes number shortcuts and the Key of a Choice choose their item right away
+func WithShortcutsChoose[T any]() SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.shortcutsChoose = true
+	}
+}
+
+// WithTypeAhead moves the cursor to the next item whose label starts with the typed letters
+func WithTypeAhead[T any]() SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.typeAhead = true
+	}
+}
+
+// WithSelectLayout arranges the items one per line, next to each other on the prompt line or in a grid
+func WithSelectLayout[T any](layout Layout) SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.layout = layout
+		s.isLevelWithPrompt = layout == LayoutHorizontal
+		if layout == LayoutHorizontal {
+			s.inputPrompt = "›"
+			s.state.promptWidth = promptWidth(s)
+		}
+	}
+}
+
+// WithSelectOther appends an item labeled label which turns into a text field when chosen.
+// The typed text is converted by parse, an error is shown beneath the field until the text changes.
+// The text field needs the vertical layout, which is used instead of any other layout.
+func WithSelectOther[T any](label string, parse func(string) (T, error)) SelectOption[T] {
+	return func(s *Input[SelectState[T]]) {
+		s.state.items = append(s.state.items, selectItem[T]{Choice: Choice[T]{Label: label}, isOther: true})
+		s.state.parseOther = parse
+		s.state.other = TextState{defaultText: []rune{}, text: []rune{}}
+	}
+}
@@ -0,0 +1,249 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"errors"
+	"github.com/liuuner/go-cli-input/colors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// fakeSource serves a SliceSource and fails once offset reaches failAt
+type fakeSource struct {
+	SliceSource[int]
+	failAt  int // -1 to never fail
+	limits  []int
+	offsets []int
+}
+
+func (f *fakeSource) Fetch(offset, limit int) ([]int, int, error) {
+	f.offsets = append(f.offsets, offset)
+	f.limits = append(f.limits, limit)
+	if f.failAt >= 0 && offset >= f.failAt {
+		return nil, 0, errors.New("fetch failed")
+	}
+	return f.SliceSource.Fetch(offset, limit)
+}
+
+func numbers(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i + 1
+	}
+	return items
+}
+
+func TestSelectFetchMore(t *testing.T) {
+	tests := []struct {
+		name          string
+		items         int
+		hideTotal     bool
+		pageSize      int
+		failAt        int
+		fetches       int // calls of fetchMore after the first page
+		wantLoaded    int
+		wantExhausted bool
+		wantErr       bool
+		wantOffsets   []int
+	}{
+		{
+			name: "first page", items: 5, pageSize: 2, failAt: -1,
+			wantLoaded: 2, wantOffsets: []int{0},
+		},
+		{
+			name: "short page exhausts", items: 5, pageSize: 2, failAt: -1, fetches: 2,
+			wantLoaded: 5, wantExhausted: true, wantOffsets: []int{0, 2, 4},
+		},
+		{
+			name: "known total exhausts without an empty page", items: 4, pageSize: 2, failAt: -1, fetches: 3,
+			wantLoaded: 4, wantExhausted: true, wantOffsets: []int{0, 2},
+		},
+		{
+			name: "unknown total needs an empty page", items: 4, hideTotal: true, pageSize: 2, failAt: -1, fetches: 3,
+			wantLoaded: 4, wantExhausted: true, wantOffsets: []int{0, 2, 4},
+		},
+		{
+			name: "unknown total not exhausted by full pages", items: 4, hideTotal: true, pageSize: 2, failAt: -1, fetches: 1,
+			wantLoaded: 4, wantOffsets: []int{0, 2},
+		},
+		{
+			name: "page size clamped to one", items: 3, pageSize: 0, failAt: -1, fetches: 1,
+			wantLoaded: 2, wantOffsets: []int{0, 1},
+		},
+		{
+			name: "error stops fetching", items: 6, pageSize: 2, failAt: 2, fetches: 3,
+			wantLoaded: 2, wantErr: true, wantOffsets: []int{0, 2},
+		},
+		{
+			name: "error on first page", items: 6, pageSize: 2, failAt: 0, fetches: 1,
+			wantLoaded: 0, wantErr: true, wantOffsets: []int{0},
+		},
+		{
+			name: "empty source", items: 0, pageSize: 2, failAt: -1, fetches: 1,
+			wantLoaded: 0, wantExhausted: true, wantOffsets: []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &fakeSource{
+				SliceSource: SliceSource[int]{Items: numbers(tt.items), HideTotal: tt.hideTotal},
+				failAt:      tt.failAt,
+			}
+			s := NewSelectSource("", source, tt.pageSize, strconv.Itoa).state
+			if len(source.offsets) != 0 {
+				t.Fatalf("constructor fetched %v", source.offsets)
+			}
+			s.fetchFirstPage()
+			for range tt.fetches {
+				s.fetchMore()
+			}
+
+			if s.loaded != tt.wantLoaded || len(s.items) != tt.wantLoaded {
+				t.Errorf("loaded = %d, items = %d, want %d", s.loaded, len(s.items), tt.wantLoaded)
+			}
+			if s.exhausted != tt.wantExhausted {
+				t.Errorf("exhausted = %v, want %v", s.exhausted, tt.wantExhausted)
+			}
+			if (s.err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", s.err, tt.wantErr)
+			}
+			if !slices.Equal(source.offsets, tt.wantOffsets) {
+				t.Errorf("offsets fetched = %v, want %v", source.offsets, tt.wantOffsets)
+			}
+			for _, limit := range source.limits {
+				if limit != max(tt.pageSize, 1) {
+					t.Errorf("limit = %d, want %d", limit, max(tt.pageSize, 1))
+				}
+			}
+			for i, item := range s.items {
+				if item.Value != i+1 || item.Label != strconv.Itoa(i+1) {
+					t.Errorf("item %d = %v %q, want %d", i, item.Value, item.Label, i+1)
+				}
+			}
+		})
+	}
+}
+
+func pointers(n int) []*int {
+	items := make([]*int, n)
+	for i := range items {
+		value := i + 1
+		items[i] = &value
+	}
+	return items
+}
+
+func parsePointer(text string) (*int, error) {
+	n, err := strconv.Atoi(text)
+	return &n, err
+}
+
+func TestSelectOtherSkipsCallbacks(t *testing.T) {
+	notNil := func(p *int) {
+		if p == nil {
+			t.Fatal("callback called with the value of the \"Other…\" item")
+		}
+	}
+
+	s := NewSelect("", pointers(2), func(p *int) string { return strconv.Itoa(*p) },
+		WithSelectOther("Other…", parsePointer),
+		WithSelectDisabled(func(p *int) (bool, string) { notNil(p); return false, "" }),
+		WithSelectDescription(func(p *int) string { notNil(p); return "" }),
+		WithSelectPreview(func(p *int) string { notNil(p); return "" }, PreviewBelow),
+	).state
+	s.GetColor = func(p *int) colors.Formatter { notNil(p); return col.Cyan }
+
+	for s.cursorPos = range s.items {
+		renderSelect(&s, true)
+		s.isDisabled(s.cursorPos)
+	}
+}
+
+func TestSelectOtherShortcut(t *testing.T) {
+	s := NewSelect("", numbers(2), strconv.Itoa,
+		WithSelectOther("Other…", strconv.Atoi),
+		WithNumberShortcuts[int](),
+		WithShortcutsChoose[int](),
+	).state
+
+	stop, err := handleSelect(&s, keys.Key{Code: keys.RuneKey, Runes: []rune{'3'}})
+	if err != nil {
+		t.Fatalf("handleSelect() error = %v", err)
+	}
+	if stop {
+		t.Error("shortcut of \"Other…\" stopped before anything was typed")
+	}
+	if !s.editing || s.cursorPos != 2 {
+		t.Errorf("editing = %v at %d, want editing at 2", s.editing, s.cursorPos)
+	}
+
+	for _, key := range []keys.Key{{Code: keys.RuneKey, Runes: []rune{'4', '2'}}, {Code: keys.Enter}} {
+		stop, err = handleSelect(&s, key)
+	}
+	if !stop || err != nil || s.Resolve() != 42 {
+		t.Errorf("stop = %v, err = %v, Resolve() = %d, want 42", stop, err, s.Resolve())
+	}
+}
+
+func TestSelectOtherFallsBackToVerticalLayout(t *testing.T) {
+	plain := NewSelect("", numbers(2), strconv.Itoa)
+	for _, layout := range []Layout{LayoutHorizontal, LayoutGrid} {
+		for _, opts := range [][]SelectOption[int]{
+			{WithSelectOther("Other…", strconv.Atoi), WithSelectLayout[int](layout)},
+			{WithSelectLayout[int](layout), WithSelectOther("Other…", strconv.Atoi)},
+		} {
+			s := NewSelect("", numbers(2), strconv.Itoa, opts...)
+			if s.state.layout != LayoutVertical || s.isLevelWithPrompt || s.inputPrompt != plain.inputPrompt {
+				t.Errorf("layout %d not replaced by the vertical layout", layout)
+			}
+		}
+	}
+}
+
+func TestSelectFetchMoreBeforeOther(t *testing.T) {
+	source := SliceSource[int]{Items: numbers(4)}
+	s := NewSelectSource("", source, 2, strconv.Itoa,
+		WithSelectOther("Other…", strconv.Atoi),
+		WithSelectPreview(strconv.Itoa, PreviewBelow),
+	).state
+	s.fetchFirstPage()
+
+	// Preview of the "Other…" index before it moves down
+	s.pane.get(2, 0)
+	s.fetchMore()
+
+	if len(s.items) != 5 || !s.items[4].isOther {
+		t.Fatalf("items = %d, want 4 and \"Other…\" last", len(s.items))
+	}
+	if previewText, ok := s.pane.get(2, s.items[2].Value); !ok || previewText != "3" {
+		t.Errorf("preview at 2 = %q, want the one of the fetched item", previewText)
+	}
+}
+
+func TestSelectEnterKeepsChoiceWhenPrefetchFails(t *testing.T) {
+	source := &fakeSource{SliceSource: SliceSource[int]{Items: numbers(8)}, failAt: 4}
+	s := NewSelectSource("", source, 4, strconv.Itoa, WithSelectCursor[int](2)).state
+	s.fetchFirstPage()
+
+	stop, err := handleSelect(&s, keys.Key{Code: keys.Enter})
+	if !stop || err != nil {
+		t.Fatalf("stop = %v, err = %v, want the choice", stop, err)
+	}
+	if s.Resolve() != 3 {
+		t.Errorf("Resolve() = %d, want 3", s.Resolve())
+	}
+}
+
+func TestSelectSourceCursorOptions(t *testing.T) {
+	source := SliceSource[int]{Items: numbers(5)}
+	s := NewSelectSource("", source, 5, strconv.Itoa,
+		WithSelectCursorFunc(func(n int) bool { return n == 4 }),
+	).state
+	s.fetchFirstPage()
+
+	if s.Resolve() != 4 {
+		t.Errorf("Resolve() = %d, want 4", s.Resolve())
+	}
+}
@@ -0,0 +1,116 @@
+package input
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Pause after which typing starts a new type-ahead search
+const typeAheadTimeout = time.Second
+
+// Label in front of the item at index naming its shortcut, "" if the Select has no shortcuts
+func (s *SelectState[T]) shortcutLabel(index int) string {
+	if !s.numberShortcuts && !s.hasKeyShortcuts() {
+		return ""
+	}
+
+	if n := s.itemNumber(index); s.numberShortcuts && n <= 9 {
+		return col.Gray(n, ")") + " "
+	}
+	if key := s.items[index].Key; key != 0 {
+		return col.Gray(string(key), ")") + " "
+	}
+	return "   "
+}
+
+// Handle a typed rune, returns whether the item it led to is chosen
+func (s *SelectState[T]) handleShortcut(r rune) (choose bool) {
+	if s.numberShortcuts && r >= '1' && r <= '9' {
+		if index := s.itemIndex(int(r - '1')); index >= 0 {
+			return s.jumpTo(index)
+		}
+		return false
+	}
+
+	for index, item := range s.items {
+		if !item.isHeader && item.Key != 0 && unicode.ToLower(item.Key) == unicode.ToLower(r) {
+			return s.jumpTo(index)
+		}
+	}
+
+	if s.typeAhead {
+		s.typeAheadSearch(r)
+	}
+	return false
+}
+
+// Move the cursor to index, returns whether the item is chosen right away
+func (s *SelectState[T]) jumpTo(index int) bool {
+	if s.isDisabled(index) {
+		return false
+	}
+	s.cursorPos = index
+	if s.items[index].isOther && s.shortcutsChoose {
+		// Choosing "Other…" means typing its value first
+		s.startEditing()
+		return false
+	}
+	return s.shortcutsChoose
+}
+
+// Move the cursor to the next item whose label starts with what was typed
+func (s *SelectState[T]) typeAheadSearch(r rune) {
+	if time.Since(s.typedAt) > typeAheadTimeout {
+		s.typed = ""
+	}
+	s.typedAt = time.Now()
+
+	// Typing the same letter again cycles through the items starting with it
+	start := s.cursorPos
+	if s.typed == "" || s.typed == string(r) {
+		s.typed = string(r)
+		start++
+	} else {
+		s.typed += string(r)
+	}
+
+	if index := s.findLabel(s.typed, start); index >= 0 {
+		s.cursorPos = index
+	} else if index := s.findLabel(string(r), s.cursorPos+1); index >= 0 {
+		s.typed = string(r)
+		s.cursorPos = index
+	}
+}
+
+// Index of the first item from start on, wrapping around, whose label starts with prefix
+func (s *SelectState[T]) findLabel(prefix string, start int) int {
+	prefix = strings.ToLower(prefix)
+	for i := 0; i < len(s.items); i++ {
+		index := (start + i) % len(s.items)
+		if !s.isDisabled(index) && strings.HasPrefix(strings.ToLower(s.items[index].Label), prefix) {
+			return index
+		}
+	}
+	return -1
+}
+
+func (s *SelectState[T]) hasKeyShortcuts() bool {
+	for _, item := range s.items {
+		if item.Key != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Position of the item at index counting from 1, group headers are not counted
+func (s *SelectState[T]) itemNumber(index int) int {
+	n := 0
+	for i := 0; i <= index; i++ {
+		if !s.items[i].isHeader {
+			n++
+		}
+	}
+	return n
+}
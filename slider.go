@@ -0,0 +1,159 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"github.com/liuuner/go-cli-input/cursor"
+	"math"
+	"strings"
+)
+
+// Number of steps PgUp and PgDown move the thumb by
+const sliderPageSteps = 10
+
+type SliderState[N Number] struct {
+	value    N
+	min, max N
+	step     N
+	unit     string // appended to the value
+	width    int    // columns of the bar
+	lines    int    // number of lines rendered last time
+}
+
+type SliderOption[N Number] func(*Input[SliderState[N]])
+
+// NewSlider lets the user choose a number between min and max by moving a thumb along a bar.
+// Left and Right move it by a step, PgUp and PgDown by ten steps, Home and End to the ends.
+func NewSlider[N Number](prompt string, min, max N, opts ...SliderOption[N]) Input[SliderState[N]] {
+	i := newInput[SliderState[N]]()
+
+	state := SliderState[N]{
+		value: min,
+		min:   min,
+		max:   max,
+		step:  1,
+		width: 30,
+	}
+
+	s := Input[SliderState[N]]{
+		render:          renderSlider[N],
+		handleInput:     handleSlider[N],
+		close:           closeSlider[N],
+		userPrompt:      prompt,
+		inputPrompt:     "› - Use arrow-keys. Return to submit.",
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return s
+}
+
+func renderSlider[N Number](s *SliderState[N], rerender bool) {
+	if !rerender {
+		cursor.Hide()
+	}
+
+	thumb := 0
+	if s.max > s.min {
+		// Subtracting in N could overflow it
+		ratio := (float64(s.value) - float64(s.min)) / (float64(s.max) - float64(s.min))
+		thumb = min(max(int(math.Round(ratio*float64(s.width-1))), 0), s.width-1)
+	}
+
+	line := "  " + col.Gray(s.format(s.min)) + " " +
+		col.Cyan(strings.Repeat("━", thumb)+"●") +
+		col.Gray(strings.Repeat("─", s.width-1-thumb)) + " " +
+		col.Gray(s.format(s.max)) + "  " +
+		col.Bold(s.format(s.value)+s.unit)
+
+	s.lines = drawLines([]string{line}, s.lines)
+}
+
+func handleSlider[N Number](s *SliderState[N], key keys.Key) (stop bool, err error) {
+	switch key.Code {
+	case keys.Left:
+		s.move(-1)
+	case keys.Right:
+		s.move(1)
+	case keys.PgDown:
+		s.move(-sliderPageSteps)
+	case keys.PgUp:
+		s.move(sliderPageSteps)
+	case keys.Home:
+		s.value = s.min
+	case keys.End:
+		s.value = s.max
+	case keys.Enter:
+		stop, err = true, nil
+	}
+
+	return
+}
+
+func closeSlider[N Number](s *SliderState[N], err error) (summary string) {
+	clearLines(s.lines)
+
+	if err != nil {
+		summary = err.Error()
+	} else {
+		summary = s.format(s.value) + s.unit
+	}
+
+	cursor.Show()
+	return
+}
+
+func (s *SliderState[N]) Resolve() N {
+	return s.value
+}
+
+// Move the thumb by steps, stopping at the ends of the bar
+func (s *SliderState[N]) move(steps int) {
+	s.value = stepNumber(s.value, s.step, steps, s.min, s.max)
+
+	if isFloat[N]() && s.value != s.max {
+		// Snap to the steps to not accumulate rounding errors
+		stepsFromMin := math.Round((float64(s.value) - float64(s.min)) / float64(s.step))
+		s.value = s.min + N(stepsFromMin*float64(s.step))
+	}
+}
+
+// Format a number with as many decimals as the step has
+func (s *SliderState[N]) format(value N) string {
+	return formatNumber(value, decimals(s.step))
+}
+
+// WithSliderValue sets the value the thumb starts at, min by default
+func WithSliderValue[N Number](value N) SliderOption[N] {
+	return func(s *Input[SliderState[N]]) {
+		s.state.value = min(max(value, s.state.min), s.state.max)
+	}
+}
+
+// WithSliderStep sets how far Left and Right move the thumb, 1 by default
+func WithSliderStep[N Number](step N) SliderOption[N] {
+	return func(s *Input[SliderState[N]]) {
+		s.state.step = step
+	}
+}
+
+// WithSliderUnit appends unit to the value as it is, like "%" or " km"
+func WithSliderUnit[N Number](unit string) SliderOption[N] {
+	return func(s *Input[SliderState[N]]) {
+		s.state.unit = unit
+	}
+}
+
+// WithSliderWidth sets the number of columns of the bar, 30 by default
+func WithSliderWidth[N Number](width int) SliderOption[N] {
+	return func(s *Input[SliderState[N]]) {
+		s.state.width = max(width, 2)
+	}
+}
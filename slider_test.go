@@ -0,0 +1,52 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"testing"
+)
+
+func TestSliderMoveStaysInRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int8
+		key   keys.KeyCode
+		want  int8
+	}{
+		{name: "right", start: 0, key: keys.Right, want: 1},
+		{name: "left", start: 0, key: keys.Left, want: -1},
+		{name: "page up", start: 0, key: keys.PgUp, want: 10},
+		{name: "page up stops at max", start: 95, key: keys.PgUp, want: 100},
+		{name: "page down stops at min", start: -95, key: keys.PgDown, want: -100},
+		{name: "home", start: 50, key: keys.Home, want: -100},
+		{name: "end", start: -50, key: keys.End, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSlider[int8]("", -100, 100, WithSliderValue(tt.start)).state
+			if _, err := handleSlider(&s, keys.Key{Code: tt.key}); err != nil {
+				t.Fatalf("handleSlider() error = %v", err)
+			}
+			if s.value != tt.want {
+				t.Errorf("value = %d, want %d", s.value, tt.want)
+			}
+		})
+	}
+}
+
+func TestSliderRenderNarrowType(t *testing.T) {
+	for _, value := range []int8{-100, 0, 100} {
+		s := NewSlider[int8]("", -100, 100, WithSliderValue(value)).state
+		// Used to panic on a negative thumb position
+		renderSlider(&s, true)
+	}
+}
+
+func TestSliderFloat32Format(t *testing.T) {
+	s := NewSlider[float32]("", 0, 1, WithSliderStep[float32](0.1))
+	state := s.state
+	state.move(3)
+	if got := closeSlider(&state, nil); got != "0.3" {
+		t.Errorf("summary = %q, want \"0.3\"", got)
+	}
+}
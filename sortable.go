@@ -0,0 +1,125 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"github.com/liuuner/go-cli-input/cursor"
+	"slices"
+	"strings"
+)
+
+type SortableState[T any] struct {
+	items      []T
+	GetName    func(T) string
+	cursorRune rune
+	grabRune   rune
+	cursorPos  int
+	grabbed    bool // the highlighted item moves with the cursor
+	lines      int  // number of lines rendered last time
+}
+
+// NewSortable lets the user reorder items. Space grabs the highlighted item, which Up and Down then move
+// through the list, and drops it again.
+func NewSortable[T any](prompt string, items []T, getName func(T) string) Input[SortableState[T]] {
+	i := newInput[SortableState[T]]()
+
+	state := SortableState[T]{
+		items:      slices.Clone(items),
+		GetName:    getName,
+		cursorRune: '❯',
+		grabRune:   '↕',
+		cursorPos:  0,
+	}
+
+	return Input[SortableState[T]]{
+		render:          renderSortable[T],
+		handleInput:     handleSortable[T],
+		close:           closeSortable[T],
+		userPrompt:      prompt,
+		inputPrompt:     "› - Use arrow-keys. Space to grab and drop. Return to submit.",
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+}
+
+func renderSortable[T any](s *SortableState[T], rerender bool) {
+	if !rerender {
+		cursor.Hide()
+	}
+
+	lines := make([]string, len(s.items))
+	for index, item := range s.items {
+		menuItemText := s.GetName(item)
+		cursorString := "   "
+		if index == s.cursorPos && s.grabbed {
+			cursorString = col.Cyan(string(s.grabRune), "  ")
+			menuItemText = col.Cyan(col.Bold(menuItemText))
+		} else if index == s.cursorPos {
+			cursorString = col.Cyan(string(s.cursorRune), "  ")
+			menuItemText = col.Underline(menuItemText)
+		}
+
+		lines[index] = cursorString + " " + menuItemText
+	}
+
+	s.lines = drawLines(lines, s.lines)
+}
+
+func handleSortable[T any](s *SortableState[T], key keys.Key) (stop bool, err error) {
+	if len(s.items) == 0 {
+		return key.Code == keys.Enter, nil
+	}
+
+	switch key.Code {
+	case keys.Up:
+		s.move(-1)
+	case keys.Down:
+		s.move(1)
+	case keys.Space:
+		s.grabbed = !s.grabbed
+	case keys.Enter:
+		s.grabbed = false
+		stop, err = true, nil
+	}
+
+	return
+}
+
+func closeSortable[T any](s *SortableState[T], err error) (summary string) {
+	clearLines(s.lines)
+
+	names := make([]string, len(s.items))
+	for i, item := range s.items {
+		names[i] = s.GetName(item)
+	}
+	summary = strings.Join(names, ", ")
+
+	if err != nil {
+		summary = err.Error()
+	}
+	cursor.Show()
+	return
+}
+
+// Resolve returns the items in the order the user put them in
+func (s *SortableState[T]) Resolve() []T {
+	return s.items
+}
+
+// Move the cursor by dir, taking the grabbed item along
+func (s *SortableState[T]) move(dir int) {
+	if !s.grabbed {
+		s.cursorPos = (s.cursorPos + dir + len(s.items)) % len(s.items)
+		return
+	}
+
+	next := s.cursorPos + dir
+	if next < 0 || next >= len(s.items) {
+		return
+	}
+	s.items[s.cursorPos], s.items[next] = s.items[next], s.items[s.cursorPos]
+	s.cursorPos = next
+}
@@ -0,0 +1,31 @@
+package input
+
+// UnknownTotal is reported by an ItemSource which does not know how many items it holds
+const UnknownTotal = -1
+
+// ItemSource provides the items of a Select page by page
+type ItemSource[T any] interface {
+	// Fetch returns up to limit items starting at offset and the total number of items or UnknownTotal.
+	// Returning fewer than limit items marks the end of the source.
+	Fetch(offset, limit int) (items []T, total int, err error)
+}
+
+// SliceSource is an in-memory ItemSource
+type SliceSource[T any] struct {
+	Items     []T
+	HideTotal bool // report UnknownTotal instead of the length of Items
+}
+
+func (s SliceSource[T]) Fetch(offset, limit int) (items []T, total int, err error) {
+	total = len(s.Items)
+	if s.HideTotal {
+		total = UnknownTotal
+	}
+
+	offset = max(offset, 0)
+	if offset >= len(s.Items) || limit <= 0 {
+		return nil, total, nil
+	}
+	end := min(offset+limit, len(s.Items))
+	return s.Items[offset:end], total, nil
+}
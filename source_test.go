@@ -0,0 +1,47 @@
+package input
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSliceSourceFetch(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name      string
+		hideTotal bool
+		offset    int
+		limit     int
+		wantItems []int
+		wantTotal int
+	}{
+		{name: "first page", offset: 0, limit: 2, wantItems: []int{1, 2}, wantTotal: 5},
+		{name: "middle page", offset: 2, limit: 2, wantItems: []int{3, 4}, wantTotal: 5},
+		{name: "last page is short", offset: 4, limit: 2, wantItems: []int{5}, wantTotal: 5},
+		{name: "offset at end", offset: 5, limit: 2, wantItems: nil, wantTotal: 5},
+		{name: "offset past end", offset: 9, limit: 2, wantItems: nil, wantTotal: 5},
+		{name: "negative offset starts at the beginning", offset: -3, limit: 2, wantItems: []int{1, 2}, wantTotal: 5},
+		{name: "zero limit", offset: 0, limit: 0, wantItems: nil, wantTotal: 5},
+		{name: "negative limit", offset: 1, limit: -1, wantItems: nil, wantTotal: 5},
+		{name: "limit beyond end", offset: 0, limit: 10, wantItems: items, wantTotal: 5},
+		{name: "hidden total", hideTotal: true, offset: 0, limit: 2, wantItems: []int{1, 2}, wantTotal: UnknownTotal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := SliceSource[int]{Items: items, HideTotal: tt.hideTotal}
+
+			got, total, err := source.Fetch(tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if !slices.Equal(got, tt.wantItems) {
+				t.Errorf("Fetch() items = %v, want %v", got, tt.wantItems)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("Fetch() total = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
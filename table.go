@@ -0,0 +1,363 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"cmp"
+	"fmt"
+	"github.com/liuuner/go-cli-input/cursor"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+)
+
+// Narrowest a column is truncated to when the table is wider than the terminal
+const minColumnWidth = 3
+
+// Column describes how a column of a table is filled and laid out
+type Column[T any] struct {
+	Header string
+	Value  func(T) string
+	Width  int // fixed width, 0 to fit the content
+	Align  Alignment
+}
+
+type tableRow[T any] struct {
+	value   T
+	index   int // position in the rows passed in
+	checked bool
+}
+
+// table holds what the single and the multi select variant of a table have in common
+type table[T any] struct {
+	rows           []tableRow[T] // in display order
+	columns        []Column[T]
+	cursorPos      int
+	sortColumn     int // -1 if the rows are in their original order
+	sortDescending bool
+	lines          int // number of lines rendered last time
+}
+
+type TableSelectState[T any] struct {
+	table[T]
+	cursorRune rune
+}
+
+type TableCheckboxState[T any] struct {
+	table[T]
+}
+
+// NewTableSelect creates a Select showing rows as an aligned table, pressing 1-9 sorts by that column
+func NewTableSelect[T any](prompt string, rows []T, columns []Column[T]) Input[TableSelectState[T]] {
+	i := newInput[TableSelectState[T]]()
+
+	state := TableSelectState[T]{
+		table:      newTable(rows, columns),
+		cursorRune: '❯',
+	}
+
+	return Input[TableSelectState[T]]{
+		render:          renderTableSelect[T],
+		handleInput:     handleTableSelect[T],
+		close:           closeTableSelect[T],
+		userPrompt:      prompt,
+		inputPrompt:     "› - Use arrow-keys. 1-9 to sort. Return to submit.",
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+}
+
+// NewTableCheckbox creates a Checkbox showing rows as an aligned table, pressing 1-9 sorts by that column
+func NewTableCheckbox[T any](prompt string, rows []T, columns []Column[T]) Input[TableCheckboxState[T]] {
+	i := newInput[TableCheckboxState[T]]()
+
+	state := TableCheckboxState[T]{
+		table: newTable(rows, columns),
+	}
+
+	return Input[TableCheckboxState[T]]{
+		render:          renderTableCheckbox[T],
+		handleInput:     handleTableCheckbox[T],
+		close:           closeTableCheckbox[T],
+		userPrompt:      prompt,
+		inputPrompt:     "› - Use arrow-keys. Space to check. 1-9 to sort. Return to submit.",
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+}
+
+func newTable[T any](rows []T, columns []Column[T]) table[T] {
+	t := table[T]{
+		rows:       make([]tableRow[T], len(rows)),
+		columns:    columns,
+		sortColumn: -1,
+	}
+	for i, row := range rows {
+		t.rows[i] = tableRow[T]{value: row, index: i}
+	}
+	return t
+}
+
+func renderTableSelect[T any](s *TableSelectState[T], rerender bool) {
+	s.render(rerender, 4, func(index int) string {
+		if index == s.cursorPos {
+			return col.Cyan(string(s.cursorRune), "   ")
+		}
+		return "    "
+	})
+}
+
+func renderTableCheckbox[T any](s *TableCheckboxState[T], rerender bool) {
+	s.render(rerender, 6, func(index int) string {
+		if s.rows[index].checked {
+			return "  [X] "
+		} else if index == s.cursorPos {
+			return fmt.Sprintf("  [%s] ", col.Gray("X"))
+		}
+		return "  [ ] "
+	})
+}
+
+// Render the header and the rows, prefix renders what is in front of a row and is prefixWidth wide
+func (t *table[T]) render(rerender bool, prefixWidth int, prefix func(index int) string) {
+	if !rerender {
+		cursor.Hide()
+	}
+
+	widths := t.columnWidths(terminalWidth() - 1 - prefixWidth)
+
+	headers := make([]string, len(t.columns))
+	for c, column := range t.columns {
+		header := column.Header
+		if c == t.sortColumn {
+			if t.sortDescending {
+				header += " ▼"
+			} else {
+				header += " ▲"
+			}
+		}
+		headers[c] = header
+	}
+
+	lines := make([]string, 0, len(t.rows)+1)
+	lines = append(lines, strings.Repeat(" ", prefixWidth)+col.Bold(t.formatRow(headers, widths)))
+	for index, row := range t.rows {
+		rowText := t.formatRow(t.cells(row.value), widths)
+		if index == t.cursorPos {
+			rowText = col.Underline(rowText)
+		}
+		lines = append(lines, prefix(index)+rowText)
+	}
+
+	t.lines = drawLines(lines, t.lines)
+}
+
+func handleTableSelect[T any](s *TableSelectState[T], key keys.Key) (stop bool, err error) {
+	if key.Code == keys.Enter {
+		return len(s.rows) > 0, nil
+	}
+	s.handleNavigation(key)
+	return
+}
+
+func handleTableCheckbox[T any](s *TableCheckboxState[T], key keys.Key) (stop bool, err error) {
+	switch key.Code {
+	case keys.Space:
+		if len(s.rows) > 0 {
+			s.rows[s.cursorPos].checked = !s.rows[s.cursorPos].checked
+		}
+	case keys.Left:
+		//select none
+		for i := range s.rows {
+			s.rows[i].checked = false
+		}
+	case keys.Right:
+		//select all
+		for i := range s.rows {
+			s.rows[i].checked = true
+		}
+	case keys.Enter:
+		stop, err = true, nil
+	default:
+		s.handleNavigation(key)
+	}
+	return
+}
+
+func (t *table[T]) handleNavigation(key keys.Key) {
+	if len(t.rows) == 0 {
+		return
+	}
+
+	switch key.Code {
+	case keys.Up:
+		t.cursorPos = (t.cursorPos - 1 + len(t.rows)) % len(t.rows)
+	case keys.Down:
+		t.cursorPos = (t.cursorPos + 1) % len(t.rows)
+	case keys.RuneKey:
+		if r := key.Runes[0]; r >= '1' && r <= '9' && int(r-'1') < len(t.columns) {
+			t.sortBy(int(r - '1'))
+		}
+	}
+}
+
+func closeTableSelect[T any](s *TableSelectState[T], err error) (summary string) {
+	clearLines(s.lines)
+
+	if err != nil {
+		summary = err.Error()
+	} else {
+		summary = s.rowName(s.Resolve())
+	}
+
+	cursor.Show()
+	return
+}
+
+func closeTableCheckbox[T any](s *TableCheckboxState[T], err error) (summary string) {
+	clearLines(s.lines)
+
+	checked := s.Resolve()
+	if len(checked) == 0 {
+		summary = "none"
+	} else {
+		names := make([]string, len(checked))
+		for i, row := range checked {
+			names[i] = s.rowName(row)
+		}
+		summary = strings.Join(names, ", ")
+	}
+
+	if err != nil {
+		summary = err.Error()
+	}
+	cursor.Show()
+	return
+}
+
+func (s *TableSelectState[T]) Resolve() T {
+	return s.rows[s.cursorPos].value
+}
+
+// Resolve returns the checked rows in the order they were passed in, regardless of sorting
+func (s *TableCheckboxState[T]) Resolve() []T {
+	rows := slices.Clone(s.rows)
+	slices.SortFunc(rows, func(a, b tableRow[T]) int {
+		return cmp.Compare(a.index, b.index)
+	})
+
+	var checked []T
+	for _, row := range rows {
+		if row.checked {
+			checked = append(checked, row.value)
+		}
+	}
+	return checked
+}
+
+// Sort the rows by a column, sorting by the same column again reverses the order.
+// The cursor stays on the row it was on.
+func (t *table[T]) sortBy(column int) {
+	if column == t.sortColumn {
+		t.sortDescending = !t.sortDescending
+	} else {
+		t.sortColumn, t.sortDescending = column, false
+	}
+
+	current := t.rows[t.cursorPos].index
+	slices.SortStableFunc(t.rows, func(a, b tableRow[T]) int {
+		order := compareCells(t.columns[column].Value(a.value), t.columns[column].Value(b.value))
+		if t.sortDescending {
+			return -order
+		}
+		return order
+	})
+	t.cursorPos = slices.IndexFunc(t.rows, func(row tableRow[T]) bool {
+		return row.index == current
+	})
+}
+
+// Compare two cells numerically if both are numbers, otherwise alphabetically
+func compareCells(a, b string) int {
+	x, errX := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errY := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if errX == nil && errY == nil {
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func (t *table[T]) cells(value T) []string {
+	cells := make([]string, len(t.columns))
+	for c, column := range t.columns {
+		cells[c] = column.Value(value)
+	}
+	return cells
+}
+
+// Name of a row in the summary, the value of its first column
+func (t *table[T]) rowName(value T) string {
+	if len(t.columns) == 0 {
+		return ""
+	}
+	return t.columns[0].Value(value)
+}
+
+// Align and truncate cells to the widths of their columns
+func (t *table[T]) formatRow(cells []string, widths []int) string {
+	formatted := make([]string, len(cells))
+	for c, cell := range cells {
+		cell = truncate(cell, widths[c])
+		if t.columns[c].Align == AlignRight {
+			formatted[c] = strings.Repeat(" ", widths[c]-visibleLen(cell)) + cell
+		} else {
+			formatted[c] = padRight(cell, widths[c])
+		}
+	}
+	return strings.Join(formatted, "  ")
+}
+
+// Widths of the columns, the widest columns are narrowed until the table fits into available columns
+func (t *table[T]) columnWidths(available int) []int {
+	widths := make([]int, len(t.columns))
+	for c, column := range t.columns {
+		if column.Width > 0 {
+			widths[c] = column.Width
+			continue
+		}
+		widths[c] = visibleLen(column.Header) + 2 // room for the sort arrow
+		for _, row := range t.rows {
+			widths[c] = max(widths[c], visibleLen(column.Value(row.value)))
+		}
+	}
+
+	gaps := 2 * max(len(widths)-1, 0)
+	for {
+		total := gaps
+		widest := 0
+		for c, width := range widths {
+			total += width
+			if width > widths[widest] {
+				widest = c
+			}
+		}
+		if total <= available || len(widths) == 0 || widths[widest] <= minColumnWidth {
+			return widths
+		}
+		widths[widest]--
+	}
+}
@@ -0,0 +1,81 @@
+package input
+
+import (
+	"github.com/containerd/console"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Width of the terminal, 80 columns if it can't be determined
+func terminalWidth() int {
+	c, err := console.ConsoleFromFile(os.Stdout)
+	if err != nil {
+		return 80
+	}
+	size, err := c.Size()
+	if err != nil || size.Width == 0 {
+		return 80
+	}
+	return int(size.Width)
+}
+
+// Number of columns s takes up, ignoring escape sequences
+func visibleLen(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if end := escapeSequenceEnd(s, i); end > i {
+			i = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		n++
+	}
+	return n
+}
+
+// Cut s down to width columns. All escape sequences are kept, also those of the part cut off,
+// so styles opened in s are closed by s itself and styles around s are left alone.
+func truncate(s string, width int) string {
+	if visibleLen(s) <= width {
+		return s
+	}
+
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(s); {
+		if end := escapeSequenceEnd(s, i); end > i {
+			b.WriteString(s[i:end])
+			i = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		if n < width-1 {
+			b.WriteString(s[i : i+size])
+		} else if n == width-1 {
+			b.WriteString("…")
+		}
+		i += size
+		n++
+	}
+	return b.String()
+}
+
+// Pad s with spaces to width columns
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-visibleLen(s), 0))
+}
+
+// Index behind the escape sequence starting at i, or i if there is none
+func escapeSequenceEnd(s string, i int) int {
+	if s[i] != '\x1b' || i+1 >= len(s) || s[i+1] != '[' {
+		return i
+	}
+	for j := i + 2; j < len(s); j++ {
+		if s[j] >= 0x40 && s[j] <= 0x7e {
+			return j + 1
+		}
+	}
+	return len(s)
+}
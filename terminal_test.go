@@ -0,0 +1,31 @@
+package input
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{name: "fits", s: "abc", width: 3, want: "abc"},
+		{name: "cut", s: "abcdef", width: 4, want: "abc…"},
+		{name: "zero width", s: "abc", width: 0, want: ""},
+		{name: "style closed by s is kept", s: "\x1b[1mabcdef\x1b[22m", width: 3, want: "\x1b[1mab…\x1b[22m"},
+		{name: "style after the cut is kept", s: "abc\x1b[4mdef\x1b[24m", width: 2, want: "a…\x1b[4m\x1b[24m"},
+		{name: "no reset of styles around s", s: "abcdef", width: 3, want: "ab…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.s, tt.width)
+			if got != tt.want {
+				t.Errorf("truncate() = %q, want %q", got, tt.want)
+			}
+			if tt.width > 0 && visibleLen(got) > tt.width {
+				t.Errorf("visibleLen() = %d, want at most %d", visibleLen(got), tt.width)
+			}
+		})
+	}
+}
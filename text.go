@@ -2,8 +2,10 @@ package input
 
 import (
 	"atomicgo.dev/keyboard/keys"
+	"context"
 	"fmt"
 	"github.com/liuuner/go-cli-input/cursor"
+	"time"
 )
 
 type TextState struct {
@@ -11,6 +13,23 @@ type TextState struct {
 	defaultText []rune
 	position    int // Cursor position within the text
 	isSensitive bool
+
+	validate     func(string) error
+	validateLive bool  // validate on every change instead of on Enter only
+	err          error // shown beneath the text until the text changes
+	errShown     bool  // a line for messages has been added beneath the text
+
+	validateAsync func(ctx context.Context, value string) error
+	debounce      time.Duration // 0 to check on Enter only
+	update        func(apply func())
+	submit        func()             // finishes the input from within update
+	cancelCheck   context.CancelFunc // cancels the running or scheduled check
+	checking      bool
+	spinnerFrame  int
+	checked       string // text the last finished check was done for
+	checkedErr    error
+	hasChecked    bool
+	submitted     bool // Enter was pressed, the text is accepted once the check passed
 }
 
 type TextOption func(*Input[TextState])
@@ -29,6 +48,7 @@ func NewText(prompt string, opts ...TextOption) Input[TextState] {
 		render:            renderText,
 		handleInput:       handleText,
 		close:             closeText,
+		attach:            attachText,
 		userPrompt:        prompt,
 		hasPrompt:         i.hasPrompt,
 		hasSummary:        i.hasSummary,
@@ -49,6 +69,8 @@ func NewText(prompt string, opts ...TextOption) Input[TextState] {
 // Render the text relative to the initial position
 func renderText(s *TextState, _ bool) {
 	if len(s.text) == 0 {
+		// Clear what was shown behind the default text
+		fmt.Printf("\033[K")
 		fmt.Print(col.Gray(string(s.makeSensitiveIfNecessary(s.defaultText))))
 		// Move cursor back to start
 		cursor.MoveHorizontally(-len(s.defaultText))
@@ -65,6 +87,37 @@ func renderText(s *TextState, _ bool) {
 		// Move cursor to the current position within the text
 		cursor.MoveHorizontally(s.position - len(s.text))
 	}
+
+	s.renderSpinner()
+	s.renderMessage()
+}
+
+// Show the error on the line beneath the text, the cursor stays where it is
+func (s *TextState) renderMessage() {
+	message := ""
+	if s.err != nil {
+		message = col.Red(s.err.Error())
+	}
+
+	if message == "" && !s.errShown {
+		return
+	}
+
+	if !s.errShown {
+		// Add the line, scrolling if the text is on the last line of the terminal
+		fmt.Print("\n")
+		cursor.Up()
+		s.errShown = true
+	}
+
+	cursor.Save()
+	cursor.DownN(1)
+	cursor.StartOfLine()
+	cursor.ClearLine()
+	if message != "" {
+		fmt.Print("  " + message)
+	}
+	cursor.Restore()
 }
 
 func (s *TextState) makeSensitiveIfNecessary(text []rune) []rune {
@@ -80,6 +133,8 @@ func (s *TextState) makeSensitiveIfNecessary(text []rune) []rune {
 }
 
 func handleText(s *TextState, key keys.Key) (stop bool, err error) {
+	before := string(s.text)
+
 	switch key.Code {
 	case keys.Left:
 		if s.position > 0 {
@@ -125,7 +180,38 @@ func handleText(s *TextState, key keys.Key) (stop bool, err error) {
 		s.position += len(key.Runes)
 		cursor.MoveHorizontally(len(key.Runes))
 	case keys.Enter:
-		stop, err = true, nil
+		if s.validate != nil {
+			s.err = s.validate(s.Resolve())
+		}
+		if s.err == nil && s.validateAsync != nil {
+			if done, _ := s.checkResult(); !done && !s.checking {
+				// Check right away instead of waiting for the debounce
+				s.stopCheck()
+				s.startCheck(0)
+			}
+			done, checkErr := s.checkResult()
+			if !done {
+				// Keys are still read while checking, so the text can be changed or the input canceled
+				s.submitted = true
+				break
+			}
+			s.err = checkErr
+		}
+		stop, err = s.err == nil, nil
+	}
+
+	if string(s.text) != before {
+		s.err = nil
+		s.submitted = false
+		if s.validateLive && s.validate != nil {
+			s.err = s.validate(s.Resolve())
+		}
+		if s.validateAsync != nil {
+			s.stopCheck()
+			if s.err == nil && s.debounce > 0 {
+				s.startCheck(s.debounce)
+			}
+		}
 	}
 
 	return
@@ -138,6 +224,13 @@ func closeText(s *TextState, err error) (summary string) {
 	// Clear the line from the current cursor to avoid overwriting
 	fmt.Printf("\033[K")
 
+	s.stopCheck()
+	if s.errShown {
+		s.err = nil
+		s.submitted = false
+		s.renderMessage()
+	}
+
 	if err != nil {
 		summary = err.Error()
 	} else {
@@ -174,3 +267,17 @@ func WithIsSensitive(b bool) TextOption {
 		s.state.isSensitive = b
 	}
 }
+
+// WithValidator refuses Enter while validate returns an error, the error is shown beneath the text until it changes
+func WithValidator(validate func(string) error) TextOption {
+	return func(s *Input[TextState]) {
+		s.state.validate = validate
+	}
+}
+
+// WithLiveValidation validates the text on every change instead of on Enter only
+func WithLiveValidation(b bool) TextOption {
+	return func(s *Input[TextState]) {
+		s.state.validateLive = b
+	}
+}
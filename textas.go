@@ -0,0 +1,64 @@
+package input
+
+import "atomicgo.dev/keyboard/keys"
+
+type TextAsState[T any] struct {
+	TextState
+	parse func(string) (T, error)
+}
+
+// NewTextAs creates a Text whose value is converted by parse. Enter is refused while parse fails,
+// its error being shown beneath the text like the one of a validator.
+func NewTextAs[T any](prompt string, parse func(string) (T, error), opts ...TextOption) Input[TextAsState[T]] {
+	t := NewText(prompt, opts...)
+
+	// Parsing is done after the validators passed in
+	validate := t.state.validate
+	t.state.validate = func(value string) error {
+		if validate != nil {
+			if err := validate(value); err != nil {
+				return err
+			}
+		}
+		_, err := parse(value)
+		return err
+	}
+
+	return Input[TextAsState[T]]{
+		render:            renderTextAs[T],
+		handleInput:       handleTextAs[T],
+		close:             closeTextAs[T],
+		attach:            attachTextAs[T],
+		userPrompt:        t.userPrompt,
+		inputPrompt:       t.inputPrompt,
+		hasPrompt:         t.hasPrompt,
+		hasSummary:        t.hasSummary,
+		failedString:      t.failedString,
+		completedString:   t.completedString,
+		promptString:      t.promptString,
+		isLevelWithPrompt: t.isLevelWithPrompt,
+		state:             TextAsState[T]{TextState: t.state, parse: parse},
+	}
+}
+
+func renderTextAs[T any](s *TextAsState[T], rerender bool) {
+	renderText(&s.TextState, rerender)
+}
+
+func handleTextAs[T any](s *TextAsState[T], key keys.Key) (stop bool, err error) {
+	return handleText(&s.TextState, key)
+}
+
+func closeTextAs[T any](s *TextAsState[T], err error) (summary string) {
+	return closeText(&s.TextState, err)
+}
+
+func attachTextAs[T any](s *TextAsState[T], update func(apply func()), submit func()) {
+	attachText(&s.TextState, update, submit)
+}
+
+// Resolve returns the parsed text
+func (s *TextAsState[T]) Resolve() T {
+	value, _ := s.parse(s.TextState.Resolve())
+	return value
+}
@@ -0,0 +1,380 @@
+package input
+
+import (
+	"atomicgo.dev/keyboard/keys"
+	"fmt"
+	"github.com/liuuner/go-cli-input/cursor"
+	"strings"
+)
+
+type treeNode[T any] struct {
+	value    T
+	parent   *treeNode[T]
+	children []*treeNode[T]
+	loaded   bool // children have been fetched
+	expanded bool
+	checked  bool
+}
+
+// tree holds what the single and the multi select variant of a tree have in common
+type tree[T any] struct {
+	roots       []*treeNode[T]
+	GetName     func(T) string
+	GetChildren func(T) ([]T, error) // called once per node when it is expanded for the first time
+	current     *treeNode[T]
+	err         error
+	lines       int // number of lines rendered last time
+}
+
+type TreeSelectState[T any] struct {
+	tree[T]
+	cursorRune rune
+}
+
+type TreeCheckboxState[T any] struct {
+	tree[T]
+}
+
+// NewTreeSelect creates a Select over nested items. Nodes are expanded with Right and collapsed with Left,
+// their children are loaded through getChildren when a node is expanded for the first time.
+func NewTreeSelect[T any](prompt string, roots []T, getName func(T) string, getChildren func(T) ([]T, error)) Input[TreeSelectState[T]] {
+	i := newInput[TreeSelectState[T]]()
+
+	state := TreeSelectState[T]{
+		tree:       newTree(roots, getName, getChildren),
+		cursorRune: '❯',
+	}
+
+	return Input[TreeSelectState[T]]{
+		render:          renderTreeSelect[T],
+		handleInput:     handleTreeSelect[T],
+		close:           closeTreeSelect[T],
+		userPrompt:      prompt,
+		inputPrompt:     "› - Use arrow-keys. Return to submit.",
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+}
+
+// NewTreeCheckbox creates a Checkbox over nested items. Checking a node checks all of its descendants,
+// a node whose children are only partly checked is shown as [-].
+func NewTreeCheckbox[T any](prompt string, roots []T, getName func(T) string, getChildren func(T) ([]T, error)) Input[TreeCheckboxState[T]] {
+	i := newInput[TreeCheckboxState[T]]()
+
+	state := TreeCheckboxState[T]{
+		tree: newTree(roots, getName, getChildren),
+	}
+
+	return Input[TreeCheckboxState[T]]{
+		render:          renderTreeCheckbox[T],
+		handleInput:     handleTreeCheckbox[T],
+		close:           closeTreeCheckbox[T],
+		userPrompt:      prompt,
+		inputPrompt:     "› - Use arrow-keys. Space to check. Return to submit.",
+		hasPrompt:       i.hasPrompt,
+		hasSummary:      i.hasSummary,
+		failedString:    i.failedString,
+		completedString: i.completedString,
+		promptString:    i.promptString,
+		state:           state,
+	}
+}
+
+func newTree[T any](roots []T, getName func(T) string, getChildren func(T) ([]T, error)) tree[T] {
+	t := tree[T]{
+		roots:       make([]*treeNode[T], len(roots)),
+		GetName:     getName,
+		GetChildren: getChildren,
+	}
+	for i, root := range roots {
+		t.roots[i] = &treeNode[T]{value: root}
+	}
+	if len(t.roots) > 0 {
+		t.current = t.roots[0]
+	}
+	return t
+}
+
+func renderTreeSelect[T any](s *TreeSelectState[T], rerender bool) {
+	s.render(rerender, func(node *treeNode[T]) string {
+		if node == s.current {
+			return col.Cyan(string(s.cursorRune), "  ")
+		}
+		return "   "
+	})
+}
+
+func renderTreeCheckbox[T any](s *TreeCheckboxState[T], rerender bool) {
+	s.render(rerender, func(node *treeNode[T]) string {
+		switch checkState(node) {
+		case checkedAll:
+			return "  [X]"
+		case checkedSome:
+			return "  [-]"
+		}
+		if node == s.current {
+			return fmt.Sprintf("  [%s]", col.Gray("X"))
+		}
+		return "  [ ]"
+	})
+}
+
+func (t *tree[T]) render(rerender bool, prefix func(node *treeNode[T]) string) {
+	if !rerender {
+		cursor.Hide()
+	}
+
+	nodes := t.visibleNodes()
+	lines := make([]string, 0, len(nodes)+1)
+	for _, node := range nodes {
+		marker := "  "
+		if !node.loaded || len(node.children) > 0 {
+			marker = "▸ "
+			if node.expanded {
+				marker = "▾ "
+			}
+		}
+
+		nodeText := t.GetName(node.value)
+		if node == t.current {
+			nodeText = col.Underline(nodeText)
+		}
+
+		indent := strings.Repeat("  ", depth(node))
+		lines = append(lines, fmt.Sprintf("%s %s%s%s", prefix(node), indent, col.Gray(marker), nodeText))
+	}
+
+	if t.err != nil {
+		lines = append(lines, "    "+col.Red(t.err.Error()))
+	}
+
+	t.lines = drawLines(lines, t.lines)
+}
+
+func handleTreeSelect[T any](s *TreeSelectState[T], key keys.Key) (stop bool, err error) {
+	if key.Code == keys.Enter {
+		return s.current != nil, nil
+	}
+	return s.handleNavigation(key)
+}
+
+func handleTreeCheckbox[T any](s *TreeCheckboxState[T], key keys.Key) (stop bool, err error) {
+	switch key.Code {
+	case keys.Space:
+		if s.current != nil {
+			setChecked(s.current, checkState(s.current) != checkedAll)
+		}
+		return false, nil
+	case keys.Enter:
+		return true, nil
+	}
+	return s.handleNavigation(key)
+}
+
+func (t *tree[T]) handleNavigation(key keys.Key) (stop bool, err error) {
+	if t.err != nil {
+		return true, t.err
+	}
+	if t.current == nil {
+		return
+	}
+
+	switch key.Code {
+	case keys.Up:
+		t.move(-1)
+	case keys.Down:
+		t.move(1)
+	case keys.Right:
+		if t.current.expanded {
+			if len(t.current.children) > 0 {
+				t.current = t.current.children[0]
+			}
+			break
+		}
+		t.expand(t.current)
+	case keys.Left:
+		if t.current.expanded {
+			t.current.expanded = false
+		} else if t.current.parent != nil {
+			t.current = t.current.parent
+		}
+	}
+
+	if t.err != nil {
+		return true, t.err
+	}
+	return
+}
+
+func closeTreeSelect[T any](s *TreeSelectState[T], err error) (summary string) {
+	clearLines(s.lines)
+
+	if err != nil {
+		summary = err.Error()
+	} else {
+		summary = s.pathName(s.Resolve())
+	}
+
+	cursor.Show()
+	return
+}
+
+func closeTreeCheckbox[T any](s *TreeCheckboxState[T], err error) (summary string) {
+	clearLines(s.lines)
+
+	paths := s.Resolve()
+	if len(paths) == 0 {
+		summary = "none"
+	} else {
+		names := make([]string, len(paths))
+		for i, path := range paths {
+			names[i] = s.pathName(path)
+		}
+		summary = strings.Join(names, ", ")
+	}
+
+	if err != nil {
+		summary = err.Error()
+	}
+	cursor.Show()
+	return
+}
+
+// Resolve returns the path from a root to the chosen node, the chosen node being the last element
+func (s *TreeSelectState[T]) Resolve() []T {
+	return path(s.current)
+}
+
+// Resolve returns the paths of the checked nodes. If all children of a node are checked
+// only the path of the node itself is returned.
+func (s *TreeCheckboxState[T]) Resolve() [][]T {
+	var paths [][]T
+	var collect func(nodes []*treeNode[T])
+	collect = func(nodes []*treeNode[T]) {
+		for _, node := range nodes {
+			switch checkState(node) {
+			case checkedAll:
+				paths = append(paths, path(node))
+			case checkedSome:
+				collect(node.children)
+			}
+		}
+	}
+	collect(s.roots)
+	return paths
+}
+
+// Nodes which are not hidden by a collapsed ancestor, in display order
+func (t *tree[T]) visibleNodes() []*treeNode[T] {
+	var nodes []*treeNode[T]
+	var walk func(level []*treeNode[T])
+	walk = func(level []*treeNode[T]) {
+		for _, node := range level {
+			nodes = append(nodes, node)
+			if node.expanded {
+				walk(node.children)
+			}
+		}
+	}
+	walk(t.roots)
+	return nodes
+}
+
+// Move the cursor by dir through the visible nodes
+func (t *tree[T]) move(dir int) {
+	nodes := t.visibleNodes()
+	for index, node := range nodes {
+		if node == t.current {
+			t.current = nodes[(index+dir+len(nodes))%len(nodes)]
+			return
+		}
+	}
+}
+
+// Load the children of node if necessary and show them
+func (t *tree[T]) expand(node *treeNode[T]) {
+	if !node.loaded {
+		children, err := t.GetChildren(node.value)
+		if err != nil {
+			t.err = err
+			return
+		}
+
+		node.children = make([]*treeNode[T], len(children))
+		for i, child := range children {
+			// A checked node passes its state on to its children
+			node.children[i] = &treeNode[T]{value: child, parent: node, checked: node.checked}
+		}
+		node.loaded = true
+	}
+	node.expanded = len(node.children) > 0
+}
+
+func (t *tree[T]) pathName(path []T) string {
+	names := make([]string, len(path))
+	for i, value := range path {
+		names[i] = t.GetName(value)
+	}
+	return strings.Join(names, " › ")
+}
+
+func path[T any](node *treeNode[T]) []T {
+	var values []T
+	for ; node != nil; node = node.parent {
+		values = append([]T{node.value}, values...)
+	}
+	return values
+}
+
+func depth[T any](node *treeNode[T]) (d int) {
+	for ; node.parent != nil; node = node.parent {
+		d++
+	}
+	return
+}
+
+const (
+	checkedNone = iota
+	checkedSome
+	checkedAll
+)
+
+// Whether none, some or all of a node is checked. Nodes whose children are not loaded have their own state.
+func checkState[T any](node *treeNode[T]) int {
+	if !node.loaded || len(node.children) == 0 {
+		if node.checked {
+			return checkedAll
+		}
+		return checkedNone
+	}
+
+	all, none := true, true
+	for _, child := range node.children {
+		switch checkState(child) {
+		case checkedAll:
+			none = false
+		case checkedSome:
+			all, none = false, false
+		case checkedNone:
+			all = false
+		}
+	}
+
+	if all {
+		return checkedAll
+	} else if none {
+		return checkedNone
+	}
+	return checkedSome
+}
+
+func setChecked[T any](node *treeNode[T], checked bool) {
+	node.checked = checked
+	for _, child := range node.children {
+		setChecked(child, checked)
+	}
+}
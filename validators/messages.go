@@ -0,0 +1,42 @@
+package validators
+
+// Messages are the texts of the errors returned by the validators.
+// They never contain the value being validated, which may be a password.
+// Regex, MinLength and MaxLength are format strings getting the pattern or the length,
+// a translation has to keep their verb, otherwise the error ends in "%!(EXTRA …)".
+type Messages struct {
+	NonEmpty  string
+	Email     string
+	URL       string
+	Regex     string // %s is the pattern
+	IP        string
+	CIDR      string
+	SemVer    string
+	MinLength string // %d is the length
+	MaxLength string // %d is the length
+	Not       string
+	Any       string // separates the errors of the validators of Any
+}
+
+// DefaultMessages are the english messages used unless SetMessages is called
+var DefaultMessages = Messages{
+	NonEmpty:  "a value is required",
+	Email:     "not a valid email address",
+	URL:       "not a valid URL",
+	Regex:     "does not match %s",
+	IP:        "not a valid IP address",
+	CIDR:      "not a valid CIDR notation",
+	SemVer:    "not a valid semantic version",
+	MinLength: "must be at least %d characters long",
+	MaxLength: "must be at most %d characters long",
+	Not:       "this value is not allowed",
+	Any:       " or ",
+}
+
+var messages = DefaultMessages
+
+// SetMessages replaces the messages of all validators, e.g. to translate them.
+// It is meant to be called once at startup, before any input is opened.
+func SetMessages(m Messages) {
+	messages = m
+}
@@ -0,0 +1,154 @@
+// Package validators provides common checks for text inputs, they plug into input.WithValidator
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Func validates a value, returning a descriptive error if it is invalid
+type Func func(string) error
+
+// Pattern of a semantic version as published on semver.org
+var semVerPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// All passes if every validator passes, returning the error of the first one failing
+func All(validators ...Func) Func {
+	return func(value string) error {
+		for _, validate := range validators {
+			if err := validate(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Any passes if at least one validator passes, otherwise the errors of all of them are returned
+func Any(validators ...Func) Func {
+	return func(value string) error {
+		var messagesOfAll []string
+		for _, validate := range validators {
+			err := validate(value)
+			if err == nil {
+				return nil
+			}
+			messagesOfAll = append(messagesOfAll, err.Error())
+		}
+		if len(messagesOfAll) == 0 {
+			return nil
+		}
+		return errors.New(strings.Join(messagesOfAll, messages.Any))
+	}
+}
+
+// Not passes if the validator fails
+func Not(validate Func) Func {
+	return func(value string) error {
+		if validate(value) == nil {
+			return errors.New(messages.Not)
+		}
+		return nil
+	}
+}
+
+// NonEmpty fails on values made of whitespace only
+func NonEmpty() Func {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New(messages.NonEmpty)
+		}
+		return nil
+	}
+}
+
+// Email passes plain addresses like "name@example.com", without a display name
+func Email() Func {
+	return func(value string) error {
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return errors.New(messages.Email)
+		}
+		return nil
+	}
+}
+
+// URL passes absolute URLs having a scheme and a host
+func URL() Func {
+	return func(value string) error {
+		u, err := url.ParseRequestURI(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New(messages.URL)
+		}
+		return nil
+	}
+}
+
+// Regex passes values matching pattern, it panics if pattern does not compile
+func Regex(pattern string) Func {
+	re := regexp.MustCompile(pattern)
+	return func(value string) error {
+		if !re.MatchString(value) {
+			return fmt.Errorf(messages.Regex, pattern)
+		}
+		return nil
+	}
+}
+
+// IP passes IPv4 and IPv6 addresses
+func IP() Func {
+	return func(value string) error {
+		if net.ParseIP(value) == nil {
+			return errors.New(messages.IP)
+		}
+		return nil
+	}
+}
+
+// CIDR passes IP networks like "192.168.0.0/16"
+func CIDR() Func {
+	return func(value string) error {
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return errors.New(messages.CIDR)
+		}
+		return nil
+	}
+}
+
+// SemVer passes semantic versions like "1.2.3-rc.1+build.5", without a leading "v"
+func SemVer() Func {
+	return func(value string) error {
+		if !semVerPattern.MatchString(value) {
+			return errors.New(messages.SemVer)
+		}
+		return nil
+	}
+}
+
+// MinLength passes values of at least n characters
+func MinLength(n int) Func {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) < n {
+			return fmt.Errorf(messages.MinLength, n)
+		}
+		return nil
+	}
+}
+
+// MaxLength passes values of at most n characters
+func MaxLength(n int) Func {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Errorf(messages.MaxLength, n)
+		}
+		return nil
+	}
+}
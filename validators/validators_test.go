@@ -0,0 +1,70 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate Func
+		value    string
+		wantErr  bool
+	}{
+		{name: "non empty", validate: NonEmpty(), value: "a"},
+		{name: "non empty blank", validate: NonEmpty(), value: "  ", wantErr: true},
+		{name: "email", validate: Email(), value: "name@example.com"},
+		{name: "email with display name", validate: Email(), value: "Name <name@example.com>", wantErr: true},
+		{name: "email without domain", validate: Email(), value: "name", wantErr: true},
+		{name: "url", validate: URL(), value: "https://example.com/path"},
+		{name: "url without scheme", validate: URL(), value: "example.com", wantErr: true},
+		{name: "regex", validate: Regex(`^[a-z]+$`), value: "abc"},
+		{name: "regex mismatch", validate: Regex(`^[a-z]+$`), value: "ABC", wantErr: true},
+		{name: "ipv4", validate: IP(), value: "192.168.0.1"},
+		{name: "ipv6", validate: IP(), value: "::1"},
+		{name: "ip invalid", validate: IP(), value: "300.1.1.1", wantErr: true},
+		{name: "cidr", validate: CIDR(), value: "10.0.0.0/8"},
+		{name: "cidr without mask", validate: CIDR(), value: "10.0.0.0", wantErr: true},
+		{name: "semver", validate: SemVer(), value: "1.2.3-rc.1+build.5"},
+		{name: "semver with v", validate: SemVer(), value: "v1.2.3", wantErr: true},
+		{name: "semver leading zero", validate: SemVer(), value: "01.2.3", wantErr: true},
+		{name: "min length counts runes", validate: MinLength(3), value: "äöü"},
+		{name: "min length short", validate: MinLength(3), value: "ab", wantErr: true},
+		{name: "max length counts runes", validate: MaxLength(3), value: "äöü"},
+		{name: "max length long", validate: MaxLength(3), value: "abcd", wantErr: true},
+		{name: "all", validate: All(NonEmpty(), MaxLength(3)), value: "abc"},
+		{name: "all one failing", validate: All(NonEmpty(), MaxLength(3)), value: "abcd", wantErr: true},
+		{name: "all of none", validate: All(), value: ""},
+		{name: "any", validate: Any(IP(), CIDR()), value: "10.0.0.0/8"},
+		{name: "any all failing", validate: Any(IP(), CIDR()), value: "nope", wantErr: true},
+		{name: "any of none", validate: Any(), value: ""},
+		{name: "not", validate: Not(Regex(`^admin$`)), value: "user"},
+		{name: "not passing", validate: Not(Regex(`^admin$`)), value: "admin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			// The value may be a password
+			if err != nil && strings.TrimSpace(tt.value) != "" && strings.Contains(err.Error(), tt.value) {
+				t.Errorf("error %q contains the value", err)
+			}
+		})
+	}
+}
+
+func TestSetMessages(t *testing.T) {
+	defer SetMessages(DefaultMessages)
+
+	translated := DefaultMessages
+	translated.MinLength = "mindestens %d Zeichen"
+	SetMessages(translated)
+
+	if err := MinLength(12)("geheim"); err == nil || err.Error() != "mindestens 12 Zeichen" {
+		t.Errorf("error = %v, want the translated message", err)
+	}
+}